@@ -0,0 +1,15 @@
+package treeprint
+
+// IndentEdgeStyle draws no connector glyphs at all, the levels of the tree being
+// told apart by their indentation only, which screen readers read out more easily.
+var IndentEdgeStyle = EdgeStyle{Link: " ", Mid: "   ", End: "   "}
+
+// WithLevelMarkers renders every Node below the first line on a line starting with
+// its depth spelled out instead of the edges, as "level 2: name", for screen readers.
+// The extra lines of multiline values and descriptions are not indented.
+// The marker is the Level message, see WithMessages.
+func WithLevelMarkers() Option {
+	return func(p *PrinterOptions) {
+		p.levelMarkers = true
+	}
+}
@@ -0,0 +1,50 @@
+package treeprint
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLevelMarkers(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewWithRoot("project")
+	src := tree.AddBranch("src")
+	src.AddMetaNode("go", "main.go")
+	src.AddNode("multi\nline")
+	tree.AddNode("README")
+
+	f := NewPrinter(WithLevelMarkers())
+	assert.Equal(`project
+level 1: src
+level 2: [go]  main.go
+level 2: multi
+line
+level 1: README
+`, string(tree.Bytes(f)))
+
+	var buf bytes.Buffer
+	r := NewIncrementalPrinter(WithLevelMarkers())
+	_, err := r.Render(&buf, tree.(*Node))
+	assert.NoError(err)
+	assert.Equal(string(tree.Bytes(f)), buf.String())
+
+	french := NewPrinter(WithLevelMarkers(), WithMessages(func(m Message, n int) string {
+		if m == Level {
+			return fmt.Sprintf("niveau %d : ", n)
+		}
+		return DefaultMessage(m, n)
+	}))
+	assert.Equal("project\nniveau 1 : src\nniveau 2 : [go]  main.go\n", tree.Print(french)[:len("project\nniveau 1 : src\nniveau 2 : [go]  main.go\n")])
+
+	assert.Equal(`project
+    src
+        [go]  main.go
+        multi
+        line
+    README
+`, string(tree.Bytes(NewPrinter(WithEdgeStyle(IndentEdgeStyle)))))
+}
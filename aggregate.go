@@ -0,0 +1,89 @@
+package treeprint
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// WeightFunc function type for reading the weight of a leaf, such as a file size, see Aggregate.
+type WeightFunc func(leaf *Node) float64
+
+// FormatFunc function type for formatting an aggregated weight, see Aggregate.
+type FormatFunc func(weight float64) string
+
+// Aggregate sums the weights of the leaves below n up into every branch, the way du does
+// for directories, and sets the meta of every Node, n included, to its total formatted
+// with format, such as HumanBytes. The weights of the leaves are read with weight
+// before their metas are replaced. The totals are added up along the traversal,
+// so they are right even if the Root of some nodes is not, as in a Cow view.
+// It returns the total of n.
+func (n *Node) Aggregate(weight WeightFunc, format FormatFunc) float64 {
+	if n == nil {
+		return 0
+	}
+	type visit struct {
+		item, parent *Node
+	}
+	var visits []visit
+	totals := make(map[*Node]float64)
+	n.VisitWithParent(func(item *Node, _ int, parent *Node) {
+		visits = append(visits, visit{item: item, parent: parent})
+		if len(item.children()) == 0 {
+			totals[item] = weight(item)
+		}
+	})
+	// in reverse pre-order every Node comes after its descendants
+	for i := len(visits) - 1; i >= 0; i-- {
+		v := visits[i]
+		totals[v.parent] += totals[v.item]
+		v.item.SetMetaValue(format(totals[v.item]))
+	}
+	total, ok := totals[n]
+	if !ok {
+		// a leaf, weighted on its own
+		total = weight(n)
+	}
+	n.SetMetaValue(format(total))
+	return total
+}
+
+// HumanBytes formats a size in bytes with binary units, as in "512 B", "1.5 KB" or "3.0 GB".
+func HumanBytes(size float64) string {
+	const units = "KMGTPE"
+	if math.Abs(size) < 1024 {
+		return strconv.FormatFloat(size, 'f', -1, 64) + " B"
+	}
+	i := -1
+	for math.Abs(size) >= 1024 && i < len(units)-1 {
+		size /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.1f %cB", size, units[i])
+}
+
+// HumanCount formats a count with decimal suffixes, as in "999", "1.2k" or "3.4M".
+func HumanCount(count float64) string {
+	const units = "kMGTPE"
+	if math.Abs(count) < 1000 {
+		return strconv.FormatFloat(count, 'f', -1, 64)
+	}
+	i := -1
+	for math.Abs(count) >= 1000 && i < len(units)-1 {
+		count /= 1000
+		i++
+	}
+	return fmt.Sprintf("%.1f%c", count, units[i])
+}
+
+// HumanDuration formats a duration in nanoseconds as time.Duration does,
+// rounded to three significant digits, as in "1.25s" or "340µs".
+func HumanDuration(ns float64) string {
+	d := time.Duration(ns)
+	if math.Abs(ns) < 1000 {
+		return d.String()
+	}
+	step := math.Pow(10, math.Floor(math.Log10(math.Abs(ns)))-2)
+	return d.Round(time.Duration(step)).String()
+}
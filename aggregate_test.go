@@ -0,0 +1,71 @@
+package treeprint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	src := tree.AddBranch("src")
+	src.AddMetaNode(int64(1536), "main.go")
+	src.AddMetaNode(int64(512), "util.go")
+	tree.AddMetaNode(int64(3<<20), "data.bin")
+	tree.AddBranch("empty")
+
+	size := func(leaf *Node) float64 {
+		if n, ok := leaf.Meta.(int64); ok {
+			return float64(n)
+		}
+		return 0
+	}
+	total := tree.Aggregate(size, HumanBytes)
+	assert.Equal(float64(3<<20+2048), total)
+	assert.Equal(`[3.0 MB]  .
+├── [2.0 KB]  src
+│   ├── [1.5 KB]  main.go
+│   └── [512 B]  util.go
+├── [3.0 MB]  data.bin
+└── [0 B]  empty
+`, tree.String())
+
+	// a leaf aggregates to its own weight
+	leaf := New()
+	leaf.SetMetaValue(int64(10))
+	assert.Equal(float64(10), leaf.Aggregate(size, HumanCount))
+	assert.Equal("10", leaf.(*Node).Meta)
+
+	// the weights go to the parent in the traversal, not to Root
+	tree = New()
+	dir := tree.AddBranch("dir").(*Node)
+	dir.Nodes = append(dir.Nodes, &Node{Meta: int64(5), Value: "orphan"})
+	assert.Equal(float64(5), tree.Aggregate(size, HumanCount))
+	assert.Equal("5", dir.Meta)
+
+	var nilNode *Node
+	assert.Zero(nilNode.Aggregate(size, HumanBytes))
+}
+
+func TestHumanize(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("0 B", HumanBytes(0))
+	assert.Equal("1023 B", HumanBytes(1023))
+	assert.Equal("1.0 KB", HumanBytes(1024))
+	assert.Equal("1.5 GB", HumanBytes(1.5*(1<<30)))
+	assert.Equal("-2.0 KB", HumanBytes(-2048))
+
+	assert.Equal("999", HumanCount(999))
+	assert.Equal("1.2k", HumanCount(1234))
+	assert.Equal("3.4M", HumanCount(3.4e6))
+
+	assert.Equal("0s", HumanDuration(0))
+	assert.Equal("340ns", HumanDuration(340))
+	assert.Equal("340µs", HumanDuration(float64(340123*time.Nanosecond)))
+	assert.Equal("1.25s", HumanDuration(float64(1254*time.Millisecond)))
+	assert.Equal("2h5m10s", HumanDuration(float64(2*time.Hour+5*time.Minute+7*time.Second)))
+}
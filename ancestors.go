@@ -0,0 +1,47 @@
+package treeprint
+
+import "bytes"
+
+// WithAncestors makes the rendering of a subtree, whose Node is not the root of its tree,
+// start with a line holding the values of the Node ancestors from the root down,
+// joined by sep and ended with a colon, as "a / b / c:". It keeps the excerpts
+// of deep trees understandable out of context. An empty sep joins them with " / ".
+func WithAncestors(sep string) Option {
+	return func(p *PrinterOptions) {
+		if sep == "" {
+			sep = " / "
+		}
+		p.ancestors, p.ancestorSep = true, sep
+	}
+}
+
+// writeAncestors writes the line of the ancestors of n, if enabled and n has any.
+func (p *printer) writeAncestors(n *Node) {
+	if !p.pf.ancestors || n.Root == nil {
+		return
+	}
+	var chain []*Node
+	for a := n.Root; a != nil; a = a.Root {
+		chain = append(chain, a)
+	}
+	line := p.line[:0]
+	for i := len(chain) - 1; i >= 0; i-- {
+		_, value, _ := p.format(chain[i], 0)
+		if i < len(chain)-1 {
+			line = append(line, p.pf.ancestorSep...)
+		}
+		// the chain stays on a single line
+		for len(value) > 0 {
+			j := bytes.IndexByte(value, '\n')
+			if j < 0 {
+				line = append(line, value...)
+				break
+			}
+			line = append(line, value[:j]...)
+			line = append(line, ' ')
+			value = value[j+1:]
+		}
+	}
+	p.line = append(line, ':', '\n')
+	p.Write(p.line)
+}
@@ -0,0 +1,31 @@
+package treeprint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAncestors(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewWithRoot("a")
+	c := tree.AddBranch("b\nmulti").AddBranch("c")
+	c.AddNode("d")
+	c.AddNode("e")
+
+	f := NewPrinter(WithAncestors(""))
+	assert.Equal(`a / b multi:
+├── c
+├── d
+└── e
+`, string(c.Bytes(f)))
+	assert.Equal("a:\n├── b\n│   multi\n└── c\n", string(tree.FindByValue("b\nmulti").Bytes(NewPrinter(WithAncestors(" > "), WithMaxDepth(1)))))
+	assert.Equal("a\n", string(NewWithRoot("a").Bytes(f)), "the root has no ancestors")
+
+	var buf bytes.Buffer
+	_, err := c.(*Node).PrintToParallel(&buf, f, 2)
+	assert.NoError(err)
+	assert.Equal(string(c.Bytes(f)), buf.String())
+}
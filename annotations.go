@@ -0,0 +1,110 @@
+package treeprint
+
+import "strings"
+
+// Annotation is a decoration added to the line of a Node at render time, see Annotations.
+type Annotation struct {
+	// Marker is printed in front of the meta and value, as a lint severity or a diff sign.
+	Marker string
+	// Suffix is printed after the value, as a coverage ratio or a lint message.
+	Suffix string
+	// Style is the style of the value, see Style.
+	Style Style
+}
+
+// Annotations is an overlay of decorations applied to a tree at render time, see WithAnnotations.
+// It leaves the tree untouched, so the same tree, even an ImmutableTree or one shared
+// by Cow handles, can be displayed with a different overlay for every consumer.
+//
+// Annotations must not be modified while rendering with them. An IncrementalPrinter
+// doesn't notice the changes to its annotations, it has to be Reset after them.
+type Annotations struct {
+	nodes map[*Node]Annotation
+	paths map[string]Annotation
+}
+
+// NewAnnotations creates an empty overlay.
+func NewAnnotations() *Annotations {
+	return &Annotations{
+		nodes: make(map[*Node]Annotation),
+		paths: make(map[string]Annotation),
+	}
+}
+
+// Set annotates the Node.
+func (a *Annotations) Set(n *Node, an Annotation) {
+	a.nodes[n] = an
+}
+
+// SetPath annotates the Node at the path of values below the root of the tree,
+// as found by FindByPath on the root, the values being compared by their %v formatting.
+// The Node doesn't need to exist yet. An empty path annotates the root.
+// The annotations set with Set take precedence.
+func (a *Annotations) SetPath(an Annotation, path ...Value) {
+	a.paths[pathKey(path)] = an
+}
+
+// Lookup returns the annotation of the Node, if any.
+func (a *Annotations) Lookup(n *Node) (Annotation, bool) {
+	if an, ok := a.nodes[n]; ok {
+		return an, true
+	}
+	if len(a.paths) == 0 {
+		return Annotation{}, false
+	}
+	var path []Value
+	for node := n; node.Root != nil; node = node.Root {
+		path = append(path, node.Value)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	an, ok := a.paths[pathKey(path)]
+	return an, ok
+}
+
+// pathKey joins the %v formatting of the values with a separator unlikely to appear in them.
+func pathKey(path []Value) string {
+	var b strings.Builder
+	for i, v := range path {
+		if i > 0 {
+			b.WriteByte(0)
+		}
+		b.WriteString(sprint(v))
+	}
+	return b.String()
+}
+
+// WithAnnotations renders the tree with the overlay of annotations.
+// The nodes printed by a RenderHook are not annotated.
+func WithAnnotations(a *Annotations) Option {
+	return func(p *PrinterOptions) {
+		p.annotations = a
+	}
+}
+
+// annotated returns the formatted meta and value of the Node with its annotation applied,
+// or unchanged if it has none. The result is only valid until the next call.
+func (p *printer) annotated(n *Node, meta, value []byte) ([]byte, []byte) {
+	if p.pf.annotations == nil {
+		return meta, value
+	}
+	an, ok := p.pf.annotations.Lookup(n)
+	if !ok {
+		return meta, value
+	}
+	b := p.annotation[:0]
+	if an.Marker != "" {
+		b = append(b, an.Marker...)
+		b = append(b, ' ')
+	}
+	b = append(b, meta...)
+	i := len(b)
+	b = an.Style.appendStyled(b, value)
+	if an.Suffix != "" {
+		b = append(b, "  "...)
+		b = append(b, an.Suffix...)
+	}
+	p.annotation = b
+	return b[:i:i], b[i:]
+}
@@ -0,0 +1,48 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	pkg := tree.AddBranch("pkg")
+	pkg.AddMetaNode("92%", "parse.go")
+	pkg.AddNode("render.go")
+	tree.AddNode("main.go")
+	plain := tree.String()
+
+	lint := NewAnnotations()
+	lint.SetPath(Annotation{Marker: "!", Suffix: "unused variable x", Style: Red}, "pkg", "render.go")
+	lint.Set(tree.FindByValue("main.go").(*Node), Annotation{Suffix: "ok"})
+	lint.SetPath(Annotation{Marker: "?"}, "missing")
+	assert.Equal(`.
+├── pkg
+│   ├── [92%]  parse.go
+│   └── ! `+"\x1b[31mrender.go\x1b[0m"+`  unused variable x
+└── main.go  ok
+`, string(tree.Bytes(NewPrinter(WithAnnotations(lint)))))
+
+	diff := NewAnnotations()
+	diff.SetPath(Annotation{Marker: "~"})
+	diff.SetPath(Annotation{Marker: "+"}, "pkg", "parse.go")
+	assert.Equal(`~ .
+├── pkg
+│   ├── + [92%]  parse.go
+│   └── render.go
+└── main.go
+`, string(tree.Bytes(NewPrinter(WithAnnotations(diff)))))
+
+	// the tree itself is left untouched
+	assert.Equal(plain, tree.String())
+
+	an, ok := diff.Lookup(tree.FindByPath("pkg", "parse.go").(*Node))
+	assert.True(ok)
+	assert.Equal("+", an.Marker)
+	_, ok = diff.Lookup(pkg.(*Node))
+	assert.False(ok)
+}
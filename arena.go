@@ -0,0 +1,62 @@
+package treeprint
+
+// DefaultArenaSlabSize is the number of nodes allocated at once by a NodeArena
+// created with a non-positive slab size.
+const DefaultArenaSlabSize = 1024
+
+// NodeArena allocates nodes in slabs instead of one heap object per Node,
+// which considerably cuts the GC work when building trees of millions of nodes.
+// The nodes of a slab are freed together, once none of them is referenced anymore.
+// A NodeArena is not safe for concurrent use.
+type NodeArena struct {
+	slabSize int
+	slab     []Node
+	interner *Interner
+}
+
+// NewNodeArena creates a NodeArena allocating slabSize nodes at once.
+func NewNodeArena(slabSize int) *NodeArena {
+	if slabSize <= 0 {
+		slabSize = DefaultArenaSlabSize
+	}
+	return &NodeArena{slabSize: slabSize}
+}
+
+// NewWithArena generates new tree with the given root value, whose nodes
+// added through AddNode, AddBranch and their meta variants come from the arena.
+func NewWithArena(root Value, arena *NodeArena) Tree {
+	n := arena.alloc()
+	n.Value = root
+	n.arena = arena
+	return n
+}
+
+func (a *NodeArena) alloc() *Node {
+	if len(a.slab) == 0 {
+		a.slab = make([]Node, a.slabSize)
+	}
+	n := &a.slab[0]
+	a.slab = a.slab[1:]
+	return n
+}
+
+// newChild creates a child of the Node, from the arena of the Node if it has one.
+func (n *Node) newChild(meta MetaValue, v Value) *Node {
+	var child *Node
+	if n.arena != nil {
+		child = n.arena.alloc()
+		child.arena = n.arena
+		if in := n.arena.interner; in != nil {
+			meta, v = in.Intern(meta), in.Intern(v)
+		}
+	} else {
+		child = new(Node)
+	}
+	child.Root = n
+	child.watched = n.watched
+	child.Meta = meta
+	child.Value = v
+	// the child is appended right after it's created
+	child.index = len(n.Nodes)
+	return child
+}
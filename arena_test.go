@@ -0,0 +1,49 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeArena(t *testing.T) {
+	assert := assert.New(t)
+
+	arena := NewNodeArena(2)
+	tree := NewWithArena(".", arena)
+	tree.AddBranch("one").AddNode("a").AddMetaBranch("m", "b").AddNode("c")
+	tree.AddMetaNode(1, "two")
+
+	assert.Equal(`.
+├── one
+│   ├── a
+│   └── [m]  b
+│       └── c
+└── [1]  two
+`, tree.String())
+	assert.NoError(tree.Validate())
+	assert.Empty(arena.slab)
+}
+
+func benchmarkBuild(b *testing.B, newTree func() Tree) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tree := newTree()
+		for j := 0; j < 1000; j++ {
+			branch := tree.AddBranch(j)
+			for k := 0; k < 100; k++ {
+				branch.AddNode(k)
+			}
+		}
+	}
+}
+
+func BenchmarkBuild(b *testing.B) {
+	benchmarkBuild(b, func() Tree { return New() })
+}
+
+func BenchmarkBuildArena(b *testing.B) {
+	benchmarkBuild(b, func() Tree {
+		return NewWithArena(".", NewNodeArena(0))
+	})
+}
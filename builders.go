@@ -0,0 +1,248 @@
+package treeprint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// FromData builds the tree of a decoded JSON or YAML document, that is of nested
+// map[string]interface{}, map[interface{}]interface{} and []interface{} values.
+// Object keys are sorted, the ones holding a scalar become "key: value" leaves and
+// the others branches named after the key. Array elements are leaves for scalars
+// and branches named "[index]" otherwise. A nil scalar is shown as null.
+// See WithMaxChildren for the options.
+func FromData(v interface{}, options ...BuildOption) Tree {
+	b := newBuildOptions(options)
+	root := &Node{Value: "."}
+	if !isContainer(v) {
+		root.Nodes = append(root.Nodes, root.newChild(nil, dataString(v)))
+		return root
+	}
+	type entry struct {
+		data interface{}
+		node *Node
+	}
+	stack := []entry{{data: v, node: root}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		var nested []entry
+		// add appends the Node of a value, the branches are expanded next
+		add := func(name string, data interface{}, scalar string) {
+			if b.full(e.node) {
+				b.omit(e.node)
+				return
+			}
+			if isContainer(data) {
+				child := e.node.newChild(nil, name)
+				e.node.Nodes = append(e.node.Nodes, child)
+				nested = append(nested, entry{data: data, node: child})
+				return
+			}
+			e.node.Nodes = append(e.node.Nodes, e.node.newChild(nil, scalar+dataString(data)))
+		}
+		switch data := e.data.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(data))
+			for k := range data {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				add(k, data[k], k+": ")
+			}
+		case map[interface{}]interface{}:
+			values := make(map[string]interface{}, len(data))
+			keys := make([]string, 0, len(data))
+			for k, v := range data {
+				key := fmt.Sprint(k)
+				keys = append(keys, key)
+				values[key] = v
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				add(k, values[k], k+": ")
+			}
+		case []interface{}:
+			for i, v := range data {
+				add(fmt.Sprintf("[%d]", i), v, "")
+			}
+		}
+		// pushed in reverse, so that the branches are expanded in order
+		for i := len(nested) - 1; i >= 0; i-- {
+			stack = append(stack, nested[i])
+		}
+	}
+	b.summarize()
+	return root
+}
+
+func isContainer(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, map[interface{}]interface{}, []interface{}:
+		return true
+	}
+	return false
+}
+
+func dataString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
+
+// KeyOrder tells the builders in which order the keys of an object become children.
+type KeyOrder int
+
+const (
+	// SortedKeys sorts the keys, so that documents with the same content give the same tree.
+	SortedKeys KeyOrder = iota
+	// DocumentOrder keeps the keys in the order they appear in the document.
+	DocumentOrder
+)
+
+// FromJSONDocument builds the tree of the JSON document read from r, shaped as FromData's,
+// with the keys of the objects in the given order. Numbers are shown as written in the document.
+// See WithMaxChildren for the options, with DocumentOrder the values left out are skipped
+// while decoding.
+func FromJSONDocument(r io.Reader, order KeyOrder, options ...BuildOption) (Tree, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var root Tree
+	if order == SortedKeys {
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("treeprint: %w", err)
+		}
+		root = FromData(v, options...)
+	} else {
+		var err error
+		if root, err = decodeDocument(dec, newBuildOptions(options)); err != nil {
+			return nil, fmt.Errorf("treeprint: %w", err)
+		}
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("treeprint: unexpected data after the JSON document")
+	}
+	return root, nil
+}
+
+// decodeDocument builds the tree of the next JSON value of dec, keeping the keys in document order.
+func decodeDocument(dec *json.Decoder, b *buildOptions) (Tree, error) {
+	type frame struct {
+		// node is nil for the containers left out, whose values are skipped
+		node   *Node
+		object bool
+		key    *string
+		i      int
+	}
+	root := &Node{Value: "."}
+	var stack []frame
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		if len(stack) == 0 {
+			if _, ok := tok.(json.Delim); !ok {
+				root.Nodes = append(root.Nodes, root.newChild(nil, dataString(tok)))
+				return root, nil
+			}
+			stack = append(stack, frame{node: root, object: tok == json.Delim('{')})
+			continue
+		}
+		top := &stack[len(stack)-1]
+		if tok == json.Delim('}') || tok == json.Delim(']') {
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				b.summarize()
+				return root, nil
+			}
+			continue
+		}
+		if top.object && top.key == nil {
+			// the decoder guarantees object keys are strings
+			key := tok.(string)
+			top.key = &key
+			continue
+		}
+		key, i := top.key, top.i
+		top.key = nil
+		top.i++
+		delim, container := tok.(json.Delim)
+		if top.node == nil || b.full(top.node) {
+			if top.node != nil {
+				b.omit(top.node)
+			}
+			if container {
+				stack = append(stack, frame{object: delim == '{'})
+			}
+			continue
+		}
+		name, scalar := fmt.Sprintf("[%d]", i), ""
+		if top.object {
+			name = *key
+			scalar = name + ": "
+		}
+		if container {
+			child := top.node.newChild(nil, name)
+			top.node.Nodes = append(top.node.Nodes, child)
+			stack = append(stack, frame{node: child, object: delim == '{'})
+			continue
+		}
+		top.node.Nodes = append(top.node.Nodes, top.node.newChild(nil, scalar+dataString(tok)))
+	}
+}
+
+// FromPaths builds the tree of the given paths, whose elements are split by sep,
+// merging the common prefixes. The nodes keep the order the paths are given in,
+// and empty elements, as in "/a//b", are skipped. See WithMaxChildren for the options.
+func FromPaths(paths []string, sep string, options ...BuildOption) Tree {
+	b := newBuildOptions(options)
+	root := &Node{Value: "."}
+	index := make(map[*Node]map[string]*Node)
+	for _, path := range paths {
+		node := root
+	elems:
+		for _, elem := range strings.Split(path, sep) {
+			if elem == "" {
+				continue
+			}
+			children := index[node]
+			if children == nil {
+				children = make(map[string]*Node)
+				index[node] = children
+			}
+			child, ok := children[elem]
+			if !ok {
+				if b.full(node) {
+					// indexed as nil, so that the child is counted once
+					b.omit(node)
+					children[elem] = nil
+					break elems
+				}
+				child = node.newChild(nil, elem)
+				node.Nodes = append(node.Nodes, child)
+				children[elem] = child
+			}
+			if child == nil {
+				// the rest of the path is below the left out child
+				break elems
+			}
+			node = child
+		}
+	}
+	b.summarize()
+	return root
+}
@@ -0,0 +1,123 @@
+package treeprint
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromData(t *testing.T) {
+	assert := assert.New(t)
+
+	var data interface{}
+	assert.NoError(json.Unmarshal([]byte(`{
+		"name": "app",
+		"deps": [{"name": "a", "dev": true}, "b", null],
+		"scripts": {"test": "go test"},
+		"empty": {}
+	}`), &data))
+	assert.Equal(`.
+├── deps
+│   ├── [0]
+│   │   ├── dev: true
+│   │   └── name: a
+│   ├── b
+│   └── null
+├── empty
+├── name: app
+└── scripts
+    └── test: go test
+`, FromData(data).String())
+
+	assert.Equal(".\n└── 42\n", FromData(42).String())
+	assert.Equal(".\n└── k\n    └── 1: v\n", FromData(map[string]interface{}{
+		"k": map[interface{}]interface{}{1: "v"},
+	}).String())
+	assert.Equal(`.
+├── deps
+│   ├── [0]
+│   │   ├── dev: true
+│   │   └── … and 1 more
+│   └── … and 2 more
+└── … and 3 more
+`, FromData(data, WithMaxChildren(1)).String())
+}
+
+func TestFromPaths(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(`.
+├── usr
+│   ├── bin
+│   │   └── go
+│   └── lib
+└── etc
+    └── hosts
+`, FromPaths([]string{"/usr/bin/go", "/usr/lib", "etc//hosts", "/usr/bin"}, "/").String())
+
+	capped := FromPaths([]string{"a/1", "a/2", "a/3/x", "a/3/y", "a/4", "b", "c", "c/z"}, "/", WithMaxChildren(2))
+	assert.Equal(`.
+├── a
+│   ├── 1
+│   ├── 2
+│   └── … and 2 more
+├── b
+└── … and 1 more
+`, capped.String())
+	assert.True(capped.FindLastNode().IsPlaceholder())
+}
+
+func TestFromJSONDocument(t *testing.T) {
+	assert := assert.New(t)
+
+	doc := `{"z": 1.50, "a": {"y": null, "b": [true, {"k": "v"}]}, "m": []}`
+	tree, err := FromJSONDocument(strings.NewReader(doc), DocumentOrder)
+	assert.NoError(err)
+	assert.Equal(`.
+├── z: 1.50
+├── a
+│   ├── y: null
+│   └── b
+│       ├── true
+│       └── [1]
+│           └── k: v
+└── m
+`, tree.String())
+
+	tree, err = FromJSONDocument(strings.NewReader(doc), SortedKeys)
+	assert.NoError(err)
+	assert.Equal(`.
+├── a
+│   ├── b
+│   │   ├── true
+│   │   └── [1]
+│   │       └── k: v
+│   └── y: null
+├── m
+└── z: 1.50
+`, tree.String())
+
+	for _, order := range []KeyOrder{SortedKeys, DocumentOrder} {
+		tree, err = FromJSONDocument(strings.NewReader(`"scalar"`), order)
+		assert.NoError(err)
+		assert.Equal(".\n└── scalar\n", tree.String())
+
+		_, err = FromJSONDocument(strings.NewReader(`{"a": [1, 2}`), order)
+		assert.Error(err)
+		_, err = FromJSONDocument(strings.NewReader(`{"a": 1`), order)
+		assert.Error(err)
+		_, err = FromJSONDocument(strings.NewReader(`{} {}`), order)
+		assert.EqualError(err, "treeprint: unexpected data after the JSON document")
+
+		tree, err = FromJSONDocument(strings.NewReader(`{"a": [1, {"x": [2]}, 3], "b": {"c": 1}, "e": 1}`), order, WithMaxChildren(1))
+		assert.NoError(err)
+		assert.Equal(`.
+├── a
+│   ├── 1
+│   └── … and 2 more
+└── … and 2 more
+`, tree.String())
+	}
+}
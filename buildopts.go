@@ -0,0 +1,56 @@
+package treeprint
+
+// BuildOption configures how the builders, FromPaths, FromEdges, FromData and
+// FromJSONDocument, construct a tree.
+type BuildOption func(*buildOptions)
+
+type buildOptions struct {
+	maxChildren int
+	messages    MessageFunc
+	// omitted counts the children left out of every capped branch, in order
+	omitted map[*Node]int
+	capped  []*Node
+}
+
+// WithMaxChildren caps the number of children of every branch to n while the tree is built:
+// the first n children are kept and the others, with their whole subtrees, are never created.
+// A capped branch ends with a Placeholder child telling how many were left out,
+// as "… and 120 more". Zero means no limit. Unlike WithMaxNodes, which only hides nodes
+// when rendering, it keeps enormous inputs from materializing in memory.
+func WithMaxChildren(n int) BuildOption {
+	return func(b *buildOptions) {
+		b.maxChildren = n
+	}
+}
+
+func newBuildOptions(options []BuildOption) *buildOptions {
+	b := &buildOptions{}
+	for _, opt := range options {
+		opt(b)
+	}
+	return b
+}
+
+// full reports whether the branch can't take any new child.
+func (b *buildOptions) full(n *Node) bool {
+	return b.maxChildren > 0 && len(n.Nodes) >= b.maxChildren
+}
+
+// omit records a child left out of the branch, the builders call it once per distinct child.
+func (b *buildOptions) omit(n *Node) {
+	if b.omitted == nil {
+		b.omitted = make(map[*Node]int)
+	}
+	if b.omitted[n] == 0 {
+		b.capped = append(b.capped, n)
+	}
+	b.omitted[n]++
+}
+
+// summarize ends the capped branches with the placeholders of their left out children.
+func (b *buildOptions) summarize() {
+	for _, n := range b.capped {
+		child := n.newChild(nil, &Placeholder{Text: b.message(MoreChildren, b.omitted[n])})
+		n.Nodes = append(n.Nodes, child)
+	}
+}
@@ -0,0 +1,81 @@
+package treeprint
+
+// Status is the progress of the task a Node stands for, see WithCheckboxes.
+type Status int
+
+const (
+	// NoStatus marks a Node that is not a task, it gets no checkbox.
+	NoStatus Status = iota
+	// Todo marks a task not started yet, rendered as "[ ]".
+	Todo
+	// InProgress marks a started task, rendered as "[~]".
+	InProgress
+	// Done marks a finished task, rendered as "[x]".
+	Done
+)
+
+func (s Status) String() string {
+	switch s {
+	case Todo:
+		return "todo"
+	case InProgress:
+		return "in-progress"
+	case Done:
+		return "done"
+	}
+	return "none"
+}
+
+// checkbox returns the glyph of the Status followed by a space, colored with ANSI escapes if asked.
+func (s Status) checkbox(colored bool) string {
+	switch s {
+	case Todo:
+		return "[ ] "
+	case InProgress:
+		if colored {
+			return "\x1b[33m[~]\x1b[0m "
+		}
+		return "[~] "
+	case Done:
+		if colored {
+			return "\x1b[32m[x]\x1b[0m "
+		}
+		return "[x] "
+	}
+	return ""
+}
+
+// WithCheckboxes renders the Status of every Node as a checkbox in front of its meta and value:
+// "[ ]" for Todo, "[~]" for InProgress and "[x]" for Done. The nodes without a Status get none.
+// If colored is set, the in-progress and done checkboxes are colored with ANSI escapes.
+func WithCheckboxes(colored bool) Option {
+	return func(p *PrinterOptions) {
+		p.checkboxes = true
+		p.checkboxColors = colored
+	}
+}
+
+// SetStatus sets the task Status of the Node, see WithCheckboxes.
+func (n *Node) SetStatus(s Status) {
+	if n == nil {
+		return
+	}
+	n.status = s
+	n.MarkDirty()
+}
+
+// Status returns the task Status of the Node, NoStatus unless set.
+func (n *Node) Status() Status {
+	if n == nil {
+		return NoStatus
+	}
+	return n.status
+}
+
+// appendCheckbox appends the checkbox of the Node if checkboxes are rendered.
+func (p *printer) appendCheckbox(line []byte, n *Node) []byte {
+	if !p.pf.checkboxes {
+		return line
+	}
+	return append(line, n.status.checkbox(p.pf.checkboxColors)...)
+}
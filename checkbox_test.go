@@ -0,0 +1,50 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckboxes(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewWithRoot("release")
+	tree.SetStatus(InProgress)
+	build := tree.AddBranch("build")
+	build.SetStatus(Done)
+	build.AddBranch("compile").SetStatus(Done)
+	build.AddBranch("test").SetStatus(Done)
+	tree.AddBranch("publish").SetStatus(Todo)
+	tree.AddNode("notes")
+
+	// no checkboxes unless asked
+	assert.Equal(`release
+├── build
+│   ├── compile
+│   └── test
+├── publish
+└── notes
+`, tree.String())
+
+	assert.Equal(`[~] release
+├── [x] build
+│   ├── [x] compile
+│   └── [x] test
+├── [ ] publish
+└── notes
+`, string(tree.Bytes(NewPrinter(WithCheckboxes(false)))))
+
+	colored := string(tree.Bytes(NewPrinter(WithCheckboxes(true))))
+	assert.Contains(colored, "├── \x1b[32m[x]\x1b[0m build\n")
+	assert.Contains(colored, "├── [ ] publish\n")
+
+	assert.Equal(Done, build.Status())
+	assert.Equal("in-progress", tree.Status().String())
+	assert.Equal(NoStatus, tree.FindByValue("notes").Status())
+	assert.Equal(Done, copyTree(tree.(*Node)).Nodes[0].Status())
+
+	var nilNode *Node
+	nilNode.SetStatus(Done)
+	assert.Equal(NoStatus, nilNode.Status())
+}
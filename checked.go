@@ -0,0 +1,187 @@
+package treeprint
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrNilValue is reported when adding a Node with a nil value.
+	ErrNilValue = errors.New("treeprint: nil value")
+	// ErrNotFound is reported when no Node matches a search.
+	ErrNotFound = errors.New("treeprint: node not found")
+	// ErrNilNode is reported when operating on a nil Node.
+	ErrNilNode = errors.New("treeprint: nil node")
+)
+
+// AddNodeE is like AddNode, but fails with ErrNilValue instead of adding a nil value.
+func (n *Node) AddNodeE(v Value) (Tree, error) {
+	return n.AddMetaNodeE(nil, v)
+}
+
+// AddMetaNodeE is like AddMetaNode, but fails with ErrNilValue instead of adding a nil value.
+func (n *Node) AddMetaNodeE(meta MetaValue, v Value) (Tree, error) {
+	if n == nil {
+		return nil, ErrNilNode
+	}
+	if v == nil {
+		return nil, ErrNilValue
+	}
+	return n.AddMetaNode(meta, v), nil
+}
+
+// AddBranchE is like AddBranch, but fails with ErrNilValue instead of adding a nil value.
+func (n *Node) AddBranchE(v Value) (Tree, error) {
+	return n.AddMetaBranchE(nil, v)
+}
+
+// AddMetaBranchE is like AddMetaBranch, but fails with ErrNilValue instead of adding a nil value.
+func (n *Node) AddMetaBranchE(meta MetaValue, v Value) (Tree, error) {
+	if n == nil {
+		return nil, ErrNilNode
+	}
+	if v == nil {
+		return nil, ErrNilValue
+	}
+	return n.AddMetaBranch(meta, v), nil
+}
+
+// FindByMetaE is like FindByMeta, but fails with ErrNotFound instead of returning nil.
+func (n *Node) FindByMetaE(meta MetaValue) (Tree, error) {
+	if n == nil {
+		return nil, ErrNilNode
+	}
+	if t := n.FindByMeta(meta); t != nil {
+		return t, nil
+	}
+	return nil, fmt.Errorf("%w: meta %v", ErrNotFound, meta)
+}
+
+// FindByValueE is like FindByValue, but fails with ErrNotFound instead of returning nil.
+func (n *Node) FindByValueE(value Value) (Tree, error) {
+	if n == nil {
+		return nil, ErrNilNode
+	}
+	if t := n.FindByValue(value); t != nil {
+		return t, nil
+	}
+	return nil, fmt.Errorf("%w: value %v", ErrNotFound, value)
+}
+
+// FindByPath follows the path of values from the Node down, each one matched against
+// the values of the children by reflect.DeepEqual, returns nil if not found.
+// An empty path gives the Node itself.
+func (n *Node) FindByPath(path ...Value) Tree {
+	t, err := n.FindByPathE(path...)
+	if err != nil {
+		return nil
+	}
+	return t
+}
+
+// FindByPathE is like FindByPath, but fails with ErrNotFound, naming the first
+// missing value, instead of returning nil.
+func (n *Node) FindByPathE(path ...Value) (Tree, error) {
+	if n == nil {
+		return nil, ErrNilNode
+	}
+	node := n
+elems:
+	for i, v := range path {
+		for _, child := range node.children() {
+			if reflect.DeepEqual(child.Value, v) {
+				node = child
+				continue elems
+			}
+		}
+		return nil, fmt.Errorf("%w: no %v below %v (path element %d)", ErrNotFound, v, node.Value, i)
+	}
+	return node, nil
+}
+
+// AddTree adds the root of another tree as a child of the Node, returning the Node itself.
+// Nothing is added when AddTreeE would fail.
+func (n *Node) AddTree(t Tree) Tree {
+	_, _ = n.AddTreeE(t)
+	return n
+}
+
+// AddTreeE adds the root of another tree as a child of the Node, returning the Node itself.
+// It fails with ErrSharedNode when the root is already held by a branch,
+// and with ErrCycle when it is the Node itself or one of its ancestors.
+func (n *Node) AddTreeE(t Tree) (Tree, error) {
+	child, _ := t.(*Node)
+	if n == nil || child == nil {
+		return nil, ErrNilNode
+	}
+	if child.Root != nil {
+		return nil, fmt.Errorf("%w: %v is held by %v", ErrSharedNode, child.Value, child.Root.Value)
+	}
+	if child.isAncestorOf(n) {
+		return nil, fmt.Errorf("%w: %v is an ancestor of %v", ErrCycle, child.Value, n.Value)
+	}
+	n.attach(child)
+	return n, nil
+}
+
+// MoveTo moves the Node with its subtree to the end of the children of parent,
+// returning the Node itself. Nothing is moved when MoveToE would fail.
+func (n *Node) MoveTo(parent Tree) Tree {
+	_, _ = n.MoveToE(parent)
+	return n
+}
+
+// MoveToE moves the Node with its subtree to the end of the children of parent,
+// returning the Node itself. It fails with ErrCycle when parent is the Node itself
+// or one of its descendants.
+func (n *Node) MoveToE(parent Tree) (Tree, error) {
+	p, _ := parent.(*Node)
+	if n == nil || p == nil {
+		return nil, ErrNilNode
+	}
+	if n.isAncestorOf(p) {
+		return nil, fmt.Errorf("%w: %v is a descendant of %v", ErrCycle, p.Value, n.Value)
+	}
+	n.detach()
+	p.attach(n)
+	return n, nil
+}
+
+// isAncestorOf reports whether n is node or one of its ancestors.
+func (n *Node) isAncestorOf(node *Node) bool {
+	for ; node != nil; node = node.Root {
+		if node == n {
+			return true
+		}
+	}
+	return false
+}
+
+// attach appends the root child to the children of n.
+func (n *Node) attach(child *Node) {
+	n.adopt(child)
+	child.Root = n
+	child.index = len(n.Nodes)
+	n.Nodes = append(n.Nodes, child)
+	n.MarkDirty()
+	n.emitAdd(child)
+}
+
+// detach removes n from the children of its Root, making it a root.
+func (n *Node) detach() {
+	parent := n.Root
+	if parent == nil {
+		return
+	}
+	if i := parent.indexOf(n); i >= 0 {
+		parent.Nodes = append(parent.Nodes[:i], parent.Nodes[i+1:]...)
+		for j := i; j < len(parent.Nodes); j++ {
+			parent.Nodes[j].index = j
+		}
+	}
+	parent.MarkDirty()
+	n.Root = nil
+	n.index = 0
+	parent.emitRemove(n)
+}
@@ -0,0 +1,128 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckedAdd(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	_, err := tree.AddNodeE(nil)
+	assert.ErrorIs(err, ErrNilValue)
+	_, err = tree.AddMetaBranchE("meta", nil)
+	assert.ErrorIs(err, ErrNilValue)
+	assert.Equal(0, tree.ChildCount())
+
+	branch, err := tree.AddBranchE("one")
+	assert.NoError(err)
+	_, err = branch.AddMetaNodeE(1, "two")
+	assert.NoError(err)
+	assert.Equal(".\n└── one\n    └── [1]  two\n", tree.String())
+}
+
+func TestCheckedFind(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("a").AddBranch("b").AddMetaNode("meta", "c")
+
+	found, err := tree.FindByPathE("a", "b", "c")
+	assert.NoError(err)
+	assert.Equal("meta", found.(*Node).Meta)
+	assert.Equal(tree, tree.FindByPath())
+
+	_, err = tree.FindByPathE("a", "x", "c")
+	assert.ErrorIs(err, ErrNotFound)
+	assert.EqualError(err, "treeprint: node not found: no x below a (path element 1)")
+	assert.Nil(tree.FindByPath("a", "x"))
+
+	_, err = tree.FindByValueE("missing")
+	assert.ErrorIs(err, ErrNotFound)
+	_, err = tree.FindByMetaE("meta")
+	assert.NoError(err)
+}
+
+func TestAddTreeMoveTo(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	a := tree.AddBranch("a")
+	b := a.AddBranch("b")
+	tree.AddNode("c")
+
+	sub := NewWithRoot("sub")
+	sub.AddNode("leaf")
+	_, err := b.AddTreeE(sub)
+	assert.NoError(err)
+	_, err = tree.AddTreeE(sub)
+	assert.ErrorIs(err, ErrSharedNode)
+	_, err = b.AddTreeE(tree)
+	assert.ErrorIs(err, ErrCycle)
+
+	_, err = a.MoveToE(sub)
+	assert.ErrorIs(err, ErrCycle)
+	_, err = a.MoveToE(a)
+	assert.ErrorIs(err, ErrCycle)
+
+	_, err = sub.MoveToE(tree)
+	assert.NoError(err)
+	assert.NoError(tree.(*Node).Validate())
+	assert.Equal(`.
+├── a
+│   └── b
+├── c
+└── sub
+    └── leaf
+`, tree.String())
+	assert.Equal(2, sub.(*Node).Index())
+
+	b.MoveTo(sub)
+	a.MoveTo(b)
+	assert.NoError(tree.(*Node).Validate())
+	assert.Equal(`.
+├── c
+└── sub
+    ├── leaf
+    └── b
+        └── a
+`, tree.String())
+}
+
+func TestDetachPromote(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	a := tree.AddBranch("a")
+	b := a.AddBranch("b")
+	b.AddNode("c")
+	a.AddNode("d")
+	tree.AddNode("e")
+
+	assert.Equal(b, b.Branch())
+	assert.Equal(a, b.(*Node).Root)
+
+	b.Promote()
+	assert.NoError(tree.(*Node).Validate())
+	assert.Equal(`.
+├── a
+│   └── d
+├── b
+│   └── c
+└── e
+`, tree.String())
+	assert.Equal(1, b.Index())
+	b.Promote()
+	assert.Equal(1, b.Index())
+
+	b.Detach()
+	assert.NoError(tree.(*Node).Validate())
+	assert.Equal(".\n├── a\n│   └── d\n└── e\n", tree.String())
+	assert.Equal("b\n└── c\n", b.String())
+	assert.Equal(-1, b.Index())
+	assert.Equal(1, tree.FindByValue("e").Index())
+	b.Detach()
+	assert.Equal("b\n└── c\n", b.String())
+}
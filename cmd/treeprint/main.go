@@ -0,0 +1,159 @@
+// Command treeprint reads a tree on stdin and prints it, converting between formats.
+//
+// Usage:
+//
+//	treeprint [-from format] [-to format] [-depth n] [-sep separator] [-keys order] [-select query]
+//
+// The input formats are:
+//
+//	indent     an outline indented with spaces or tabs (default)
+//	paths      one path per line, split by the -sep separator
+//	json       a JSON document
+//	yaml       a YAML document
+//	tree       a tree rendered by treeprint
+//	tree-json  a tree encoded as JSON by treeprint
+//
+// The output formats are "text" (default), "ascii", "json" (readable back as tree-json),
+// "paths" and "leaves", printing the paths to all the nodes or to the leaves only,
+// joined by the -sep separator, for shell completion or fuzzy finders, and "plain" and "levels",
+// indenting the nodes without edges or prefixing them with their level, for screen readers.
+// The keys of JSON objects are sorted, unless -keys is "document" to keep them in document order.
+// With -select, only the subtrees selected by the query are printed, one after the other,
+// see treeprint.CompileQuery for the syntax.
+//
+// For instance:
+//
+//	find . -type f | treeprint -from paths
+//	kubectl get pod -o yaml | treeprint -from yaml -depth 2
+//	kubectl get pod -o yaml | treeprint -from yaml -select 'spec/containers/*/image'
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ououmania/treeprint"
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, in io.Reader, out io.Writer) error {
+	fs := flag.NewFlagSet("treeprint", flag.ContinueOnError)
+	from := fs.String("from", "indent", "input `format`: indent, paths, json, yaml, tree or tree-json")
+	to := fs.String("to", "text", "output `format`: text, ascii, json, paths, leaves, plain or levels")
+	depth := fs.Int("depth", 0, "maximum depth to print, 0 for no limit")
+	sep := fs.String("sep", "/", "path separator of the paths formats")
+	keys := fs.String("keys", "sorted", "`order` of the JSON object keys: sorted or document")
+	query := fs.String("select", "", "`query` selecting the subtrees to print")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("treeprint: unexpected arguments %q", fs.Args())
+	}
+
+	var order treeprint.KeyOrder
+	switch *keys {
+	case "sorted":
+		order = treeprint.SortedKeys
+	case "document":
+		order = treeprint.DocumentOrder
+	default:
+		return fmt.Errorf("treeprint: unknown key order %q", *keys)
+	}
+	tree, err := read(*from, *sep, order, in)
+	if err != nil {
+		return err
+	}
+	if *query == "" {
+		return write(tree, *to, *depth, *sep, out)
+	}
+	selected, err := tree.Query(*query)
+	if err != nil {
+		return err
+	}
+	for _, t := range selected {
+		// printed as roots rather than as children
+		t.Detach()
+		if err := write(t, *to, *depth, *sep, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func write(tree treeprint.Tree, to string, depth int, sep string, out io.Writer) error {
+	var err error
+	switch to {
+	case "text":
+		_, err = tree.PrintTo(out, treeprint.NewPrinter(treeprint.WithMaxDepth(depth)))
+	case "ascii":
+		_, err = tree.PrintTo(out, treeprint.NewPrinter(treeprint.WithMaxDepth(depth),
+			treeprint.WithEdgeStyle(treeprint.ASCIIEdgeStyle)))
+	case "plain":
+		_, err = tree.PrintTo(out, treeprint.NewPrinter(treeprint.WithMaxDepth(depth),
+			treeprint.WithEdgeStyle(treeprint.IndentEdgeStyle)))
+	case "levels":
+		_, err = tree.PrintTo(out, treeprint.NewPrinter(treeprint.WithMaxDepth(depth),
+			treeprint.WithLevelMarkers()))
+	case "json":
+		if depth > 0 {
+			for _, node := range tree.NodesAtDepth(depth) {
+				node.Nodes = nil
+			}
+		}
+		var b []byte
+		if b, err = tree.MarshalJSON(); err == nil {
+			_, err = fmt.Fprintf(out, "%s\n", b)
+		}
+	case "paths", "leaves":
+		err = tree.WritePaths(out, sep, to == "leaves")
+	default:
+		err = fmt.Errorf("treeprint: unknown output format %q", to)
+	}
+	return err
+}
+
+func read(format, sep string, order treeprint.KeyOrder, in io.Reader) (treeprint.Tree, error) {
+	switch format {
+	case "indent":
+		return treeprint.ParseIndented(in)
+	case "paths":
+		var paths []string
+		s := bufio.NewScanner(in)
+		for s.Scan() {
+			paths = append(paths, s.Text())
+		}
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+		return treeprint.FromPaths(paths, sep), nil
+	case "json":
+		return treeprint.FromJSONDocument(in, order)
+	case "yaml":
+		var data interface{}
+		if err := yaml.NewDecoder(in).Decode(&data); err != nil {
+			return nil, fmt.Errorf("treeprint: yaml: %w", err)
+		}
+		return treeprint.FromData(data), nil
+	case "tree":
+		return treeprint.Parse(in)
+	case "tree-json":
+		b, err := io.ReadAll(in)
+		if err != nil {
+			return nil, err
+		}
+		tree := treeprint.New()
+		return tree, tree.UnmarshalJSON(b)
+	}
+	return nil, fmt.Errorf("treeprint: unknown input format %q", format)
+}
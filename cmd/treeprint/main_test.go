@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+
+	convert := func(input string, args ...string) string {
+		out := new(strings.Builder)
+		assert.NoError(run(args, strings.NewReader(input), out))
+		return out.String()
+	}
+	assert.Equal(".\n├── a\n│   └── b\n└── c\n", convert("a\n  b\nc\n"))
+	assert.Equal(".\n`-- usr\n    |-- bin\n    `-- lib\n", convert("/usr/bin\n/usr/lib\n", "-from", "paths", "-to", "ascii"))
+	assert.Equal(".\n├── a: 1\n└── b\n", convert(`{"a": 1, "b": [true]}`, "-from", "json", "-depth", "1"))
+	assert.Equal(".\n├── a: 1\n└── b: 2\n", convert(`{"b": 2, "a": 1}`, "-from", "json"))
+	assert.Equal(".\n├── b: 2\n└── a: 1\n", convert(`{"b": 2, "a": 1}`, "-from", "json", "-keys", "document"))
+	assert.Equal(".\n└── a\n    └── b: c\n", convert("a:\n  b: c\n", "-from", "yaml"))
+
+	text := ".\n├── [m]  a\n│   └── b\n└── c\n"
+	j := convert(text, "-from", "tree", "-to", "json")
+	assert.Equal(`{"value":".","children":[{"value":"a","meta":"m","children":[{"value":"b"}]},{"value":"c"}]}`+"\n", j)
+	assert.Equal(text, convert(j, "-from", "tree-json"))
+
+	assert.Equal("usr\nusr/bin\nusr/lib\nusr/lib/go\n", convert("/usr/bin\n/usr/lib/go\n", "-from", "paths", "-to", "paths"))
+	assert.Equal("usr:bin\nusr:lib:go\n", convert("usr\n bin\n lib\n  go\n", "-to", "leaves", "-sep", ":"))
+	assert.Equal(".\n    usr\n        bin\n        lib\n            go\n", convert("usr\n bin\n lib\n  go\n", "-to", "plain"))
+	assert.Equal(".\nlevel 1: usr\nlevel 2: bin\nlevel 2: lib\nlevel 3: go\n", convert("usr\n bin\n lib\n  go\n", "-to", "levels"))
+
+	deploy := "services\n  api\n    image: api:1.2\n  db\n    image: postgres:15\n"
+	assert.Equal("image: api:1.2\nimage: postgres:15\n", convert(deploy, "-select", "services/*/image*"))
+	assert.Equal("db\n└── image: postgres:15\n", convert(deploy, "-select", "**/db"))
+
+	assert.EqualError(run([]string{"-from", "xml"}, strings.NewReader(""), new(strings.Builder)),
+		`treeprint: unknown input format "xml"`)
+	assert.EqualError(run([]string{"-to", "xml"}, strings.NewReader(""), new(strings.Builder)),
+		`treeprint: unknown output format "xml"`)
+	assert.EqualError(run([]string{"-select", "a["}, strings.NewReader("a\n"), new(strings.Builder)),
+		`treeprint: invalid query "a[" at offset 2: unknown field ""`)
+}
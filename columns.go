@@ -0,0 +1,102 @@
+package treeprint
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// ColumnsFunc function type for producing the cells of the columns following the tree
+// on the line of a Node, see PrintColumns.
+type ColumnsFunc func(n *Node) []string
+
+// PrintColumns renders the tree or subtree as a table whose first column is the tree itself
+// and the other ones are the cells produced by columns for every Node, aligned across
+// the whole output, as in `tree -pugh` or `kubectl get`. When headers are given, the first
+// line holds them, the first header being the one of the tree column.
+// The widths are measured by PrinterOptions.Measure and the columns are separated by two spaces.
+// The extra lines of multiline values, and the line ending a truncated output, have no cells.
+// It returns the number of bytes written and the first error encountered.
+func (n *Node) PrintColumns(w io.Writer, f PrinterOptions, columns ColumnsFunc, headers ...string) (int64, error) {
+	if n == nil {
+		return 0, nil
+	}
+	type row struct {
+		node  *Node
+		start int64
+	}
+	var (
+		rows []row
+		out  bytes.Buffer
+	)
+	// the output is encoded once the cells are laid out
+	p := newPrinter(&out, f.raw())
+	p.onLine = func(node *Node, _ int, start, _ int64) {
+		rows = append(rows, row{node: node, start: start})
+	}
+	n.render(p)
+	err := p.err
+	p.release()
+	if err != nil {
+		return 0, err
+	}
+
+	// the measuring pass, cells[0] holds the first line of the Node
+	b := out.Bytes()
+	table := make([][]string, 0, len(rows)+1)
+	if len(headers) > 0 {
+		table = append(table, headers)
+	}
+	for _, r := range rows {
+		line := b[r.start:]
+		if i := bytes.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i]
+		}
+		table = append(table, append([]string{string(line)}, columns(r.node)...))
+	}
+	var widths []int
+	for _, cells := range table {
+		for i, cell := range cells {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := f.Measure(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	// the layout pass, the lines of the nodes get their cells appended
+	var dst bytes.Buffer
+	appendRow := func(cells []string) {
+		for i, cell := range cells {
+			if i > 0 {
+				dst.WriteString("  ")
+			}
+			dst.WriteString(cell)
+			if i < len(cells)-1 {
+				dst.WriteString(strings.Repeat(" ", widths[i]-f.Measure(cell)))
+			}
+		}
+		dst.WriteByte('\n')
+	}
+	if len(headers) > 0 {
+		appendRow(headers)
+		table = table[1:]
+	}
+	var offset int64
+	for i, r := range rows {
+		// the lines without a Node are written as they are
+		dst.Write(b[offset:r.start])
+		appendRow(table[i])
+		offset = r.start + int64(len(table[i][0])) + 1
+		if offset > int64(len(b)) {
+			offset = int64(len(b))
+		}
+	}
+	dst.Write(b[offset:])
+	enc := newPrinter(w, PrinterOptions{lineEnding: f.lineEnding, transformer: f.transformer})
+	defer enc.release()
+	enc.Write(dst.Bytes())
+	return enc.n, enc.err
+}
@@ -0,0 +1,61 @@
+package treeprint
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintColumns(t *testing.T) {
+	assert := assert.New(t)
+
+	type file struct {
+		mode string
+		size int
+	}
+	tree := NewWithRoot("project")
+	src := tree.AddMetaBranch(file{"drwxr-xr-x", 4096}, "src")
+	src.AddMetaNode(file{"-rw-r--r--", 1532}, "main.go")
+	src.AddMetaNode(file{"-rw-r--r--", 87}, "文档.md")
+	tree.AddMetaNode(file{"-rwxr-xr-x", 12}, "run\nall")
+	tree.AddNode("LICENSE")
+
+	columns := func(n *Node) []string {
+		f, ok := n.Meta.(file)
+		if !ok {
+			return nil
+		}
+		return []string{f.mode, fmt.Sprint(f.size)}
+	}
+	f := NewPrinter(WithMetaFunc(nil))
+	buf := new(bytes.Buffer)
+	written, err := tree.PrintColumns(buf, f, columns, "NAME", "MODE", "SIZE")
+	assert.NoError(err)
+	assert.Equal(int64(buf.Len()), written)
+	assert.Equal(`NAME             MODE        SIZE
+project
+├── src          drwxr-xr-x  4096
+│   ├── main.go  -rw-r--r--  1532
+│   └── 文档.md  -rw-r--r--  87
+├── run          -rwxr-xr-x  12
+│   all
+└── LICENSE
+`, buf.String())
+
+	buf.Reset()
+	_, err = tree.PrintColumns(buf, NewPrinter(WithMetaFunc(nil), WithMaxNodes(2), WithStyleRules(StyleRule{Match: Leaves, Style: Bold})), columns)
+	assert.NoError(err)
+	assert.Equal("project\n"+
+		"├── src          drwxr-xr-x  4096\n"+
+		"│   ├── \x1b[1mmain.go\x1b[0m  -rw-r--r--  1532\n"+
+		"… output truncated (3 nodes omitted)\n", buf.String())
+
+	buf.Reset()
+	_, err = tree.FindByValue("src").(*Node).Detach().(*Node).PrintColumns(buf, NewPrinter(WithMetaFunc(nil), WithLineEnding("\r\n")), columns)
+	assert.NoError(err)
+	assert.Equal("src          drwxr-xr-x  4096\r\n"+
+		"├── main.go  -rw-r--r--  1532\r\n"+
+		"└── 文档.md  -rw-r--r--  87\r\n", buf.String(), "the line endings are applied once the cells are laid out")
+}
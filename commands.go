@@ -0,0 +1,61 @@
+package treeprint
+
+import (
+	"flag"
+	"strings"
+)
+
+// Command is a command of a CLI command tree, such as *cobra.Command.
+type Command[C any] interface {
+	Name() string
+	Commands() []C
+}
+
+// FromCommands builds the tree of a CLI command hierarchy, the nodes being the command
+// names. When flags is not nil, the flags it returns for a command are joined into the meta
+// of its Node. For instance, with cobra:
+//
+//	tree := treeprint.FromCommands(rootCmd, func(c *cobra.Command) []string {
+//		var flags []string
+//		c.LocalFlags().VisitAll(func(f *pflag.Flag) {
+//			flags = append(flags, "--"+f.Name)
+//		})
+//		return flags
+//	})
+func FromCommands[C Command[C]](root C, flags func(C) []string) Tree {
+	meta := func(c C) MetaValue {
+		if flags == nil {
+			return nil
+		}
+		if f := flags(c); len(f) > 0 {
+			return strings.Join(f, " ")
+		}
+		return nil
+	}
+	n := &Node{Meta: meta(root), Value: root.Name()}
+	type entry struct {
+		cmd  C
+		node *Node
+	}
+	stack := []entry{{cmd: root, node: n}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, cmd := range e.cmd.Commands() {
+			child := e.node.newChild(meta(cmd), cmd.Name())
+			e.node.Nodes = append(e.node.Nodes, child)
+			stack = append(stack, entry{cmd: cmd, node: child})
+		}
+	}
+	return n
+}
+
+// FlagNames returns the names of the flags defined in fs, prefixed with a dash,
+// to be used as the flags of FromCommands.
+func FlagNames(fs *flag.FlagSet) []string {
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) {
+		names = append(names, "-"+f.Name)
+	})
+	return names
+}
@@ -0,0 +1,43 @@
+package treeprint
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCommand struct {
+	name     string
+	flags    *flag.FlagSet
+	commands []*testCommand
+}
+
+func (c *testCommand) Name() string             { return c.name }
+func (c *testCommand) Commands() []*testCommand { return c.commands }
+
+func TestFromCommands(t *testing.T) {
+	assert := assert.New(t)
+
+	newCommand := func(name string, flags ...string) *testCommand {
+		fs := flag.NewFlagSet(name, flag.ContinueOnError)
+		for _, f := range flags {
+			fs.Bool(f, false, "")
+		}
+		return &testCommand{name: name, flags: fs}
+	}
+	root := newCommand("mycli", "v")
+	get := newCommand("get", "o", "all")
+	get.commands = []*testCommand{newCommand("pods"), newCommand("nodes", "wide")}
+	root.commands = []*testCommand{get, newCommand("version")}
+
+	assert.Equal(`[-v]  mycli
+├── [-all -o]  get
+│   ├── pods
+│   └── [-wide]  nodes
+└── version
+`, FromCommands(root, func(c *testCommand) []string {
+		return FlagNames(c.flags)
+	}).String())
+	assert.Equal("mycli\n├── get\n│   ├── pods\n│   └── nodes\n└── version\n", FromCommands(root, nil).String())
+}
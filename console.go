@@ -0,0 +1,42 @@
+package treeprint
+
+import (
+	"os"
+	"strings"
+)
+
+// ConsoleEdgesEnv is the environment variable overriding the detection of WithConsoleEdges,
+// it can be set to "ascii" or "unicode". Users of screen readers can set it to "plain"
+// for IndentEdgeStyle or to "levels" for WithLevelMarkers.
+const ConsoleEdgesEnv = "TREEPRINT_EDGES"
+
+// WithConsoleEdges draws the tree with ASCIIEdgeStyle when the console can't display
+// the Unicode box-drawing characters, as legacy Windows consoles using an OEM code page.
+// The detection is overridden by the ConsoleEdgesEnv environment variable.
+// It's meant for the output written to the console, not to files.
+func WithConsoleEdges() Option {
+	return func(p *PrinterOptions) {
+		switch strings.ToLower(os.Getenv(ConsoleEdgesEnv)) {
+		case "plain":
+			p.edges = IndentEdgeStyle
+			return
+		case "levels":
+			p.levelMarkers = true
+			return
+		}
+		if ConsoleNeedsASCII() {
+			p.edges = ASCIIEdgeStyle
+		}
+	}
+}
+
+// ConsoleNeedsASCII reports whether the console can't display the Unicode box-drawing characters.
+func ConsoleNeedsASCII() bool {
+	switch strings.ToLower(os.Getenv(ConsoleEdgesEnv)) {
+	case "ascii":
+		return true
+	case "unicode":
+		return false
+	}
+	return legacyConsole()
+}
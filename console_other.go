@@ -0,0 +1,9 @@
+//go:build !windows
+
+package treeprint
+
+// legacyConsole reports whether the console can't display the box-drawing characters,
+// the consoles of the other systems are expected to handle UTF-8.
+func legacyConsole() bool {
+	return false
+}
@@ -0,0 +1,27 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsoleEdges(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddNode("one")
+
+	t.Setenv(ConsoleEdgesEnv, "ASCII")
+	assert.True(ConsoleNeedsASCII())
+	assert.Equal(".\n`-- one\n", string(tree.Bytes(NewPrinter(WithConsoleEdges()))))
+
+	t.Setenv(ConsoleEdgesEnv, "unicode")
+	assert.False(ConsoleNeedsASCII())
+	assert.Equal(tree.String(), string(tree.Bytes(NewPrinter(WithConsoleEdges()))))
+
+	t.Setenv(ConsoleEdgesEnv, "plain")
+	assert.Equal(".\n    one\n", string(tree.Bytes(NewPrinter(WithConsoleEdges()))))
+	t.Setenv(ConsoleEdgesEnv, "levels")
+	assert.Equal(".\nlevel 1: one\n", string(tree.Bytes(NewPrinter(WithConsoleEdges()))))
+}
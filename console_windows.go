@@ -0,0 +1,18 @@
+package treeprint
+
+import "syscall"
+
+// utf8CodePage is the code page of UTF-8 consoles.
+const utf8CodePage = 65001
+
+var procGetConsoleOutputCP = syscall.NewLazyDLL("kernel32.dll").NewProc("GetConsoleOutputCP")
+
+// legacyConsole reports whether the console uses a code page other than UTF-8,
+// which can't display the box-drawing characters. Without a console there's nothing to check.
+func legacyConsole() bool {
+	if procGetConsoleOutputCP.Find() != nil {
+		return false
+	}
+	cp, _, _ := procGetConsoleOutputCP.Call()
+	return cp != 0 && cp != utf8CodePage
+}
@@ -0,0 +1,102 @@
+package treeprint
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// ControlChars tells how the control characters of the metas and values are rendered.
+type ControlChars int
+
+const (
+	// KeepControlChars writes the control characters as they are, it is the default.
+	KeepControlChars ControlChars = iota
+	// EscapeControlChars writes the control characters as Go escape sequences, as in \r or \x1b.
+	EscapeControlChars
+	// StripControlChars leaves the control characters out.
+	StripControlChars
+)
+
+// WithControlChars sets how the control characters of the metas and values are rendered,
+// so that values holding carriage returns, terminal escape sequences or binary data can't
+// corrupt the output or fake its structure. Line breaks and tabs are not affected.
+// The Unicode bidirectional formatting characters are treated as control characters as well.
+func WithControlChars(mode ControlChars) Option {
+	return func(p *PrinterOptions) {
+		p.controlChars = mode
+	}
+}
+
+// WithKeepColors keeps the SGR escape sequences setting the colors and the text attributes,
+// as in "\x1b[1;31m", when the control characters are escaped or stripped.
+func WithKeepColors() Option {
+	return func(p *PrinterOptions) {
+		p.keepColors = true
+	}
+}
+
+// isControl reports whether r is a control character as meant by WithControlChars.
+func isControl(r rune) bool {
+	switch {
+	case r == '\n' || r == '\t':
+		return false
+	case r < 0x20 || r >= 0x7f && r <= 0x9f:
+		return true
+	case r >= 0x202a && r <= 0x202e, r >= 0x2066 && r <= 0x2069:
+		return true
+	}
+	return false
+}
+
+// sgrLen returns the length of the SGR escape sequence b starts with, or 0.
+func sgrLen(b []byte) int {
+	if len(b) < 3 || b[0] != 0x1b || b[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(b); i++ {
+		switch c := b[i]; {
+		case c == 'm':
+			return i + 1
+		case c != ';' && (c < '0' || c > '9'):
+			return 0
+		}
+	}
+	return 0
+}
+
+// appendControl appends src to dst, escaping or stripping its control characters
+// as set by the printer options.
+func (f PrinterOptions) appendControl(dst, src []byte) []byte {
+	for len(src) > 0 {
+		if f.keepColors {
+			if n := sgrLen(src); n > 0 {
+				dst = append(dst, src[:n]...)
+				src = src[n:]
+				continue
+			}
+		}
+		r, size := utf8.DecodeRune(src)
+		if r == utf8.RuneError || !isControl(r) {
+			dst = append(dst, src[:size]...)
+		} else if f.controlChars == EscapeControlChars {
+			n := len(dst)
+			dst = strconv.AppendQuoteRuneToASCII(dst, r)
+			// drop the quotes around the escape sequence
+			dst = append(dst[:n], dst[n+1:len(dst)-1]...)
+		}
+		src = src[size:]
+	}
+	return dst
+}
+
+// hasControl reports whether b holds a control character.
+func hasControl(b []byte) bool {
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if isControl(r) {
+			return true
+		}
+		b = b[size:]
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControlChars(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddMetaNode("\x00meta", "carriage\rreturn")
+	tree.AddNode("\x1b[31mred\x1b[0m and \x1b]0;title\x07")
+	tree.AddNode("multi\nline\twith\u202etab")
+
+	assert.Equal(`.
+├── [\x00meta]  carriage\rreturn
+├── \x1b[31mred\x1b[0m and \x1b]0;title\a
+└── multi
+    line	with\u202etab
+`, string(tree.Bytes(NewPrinter(WithControlChars(EscapeControlChars)))))
+
+	assert.Equal(".\n"+
+		"├── [meta]  carriagereturn\n"+
+		"├── \x1b[31mred\x1b[0m and ]0;title\n"+
+		"└── multi\n"+
+		"    line\twithtab\n",
+		string(tree.Bytes(NewPrinter(WithControlChars(StripControlChars), WithKeepColors()))))
+
+	assert.Contains(tree.String(), "[\x00meta]  carriage\rreturn\n")
+}
@@ -0,0 +1,130 @@
+package treeprint
+
+// Cow is a copy-on-write handle over a shared base tree.
+// The base tree is never modified through it: the first change to a Node copies
+// that Node together with its ancestors, while all the unchanged subtrees
+// keep being shared with the base tree.
+//
+// Any number of Cow handles may share one base tree across goroutines, and render
+// their views concurrently, as long as the base tree itself is not modified: the lazy
+// children of the shared nodes are produced once and the ValueCache entries are stored
+// under a lock. A single Cow is not safe for concurrent use.
+//
+// The unchanged nodes shared with the base tree keep their base parent as Root,
+// so their Ancestors and Index are the ones of the base tree. Parent, Ancestors and
+// Index give them in the view instead.
+type Cow struct {
+	base   *Node
+	root   *Node
+	copies map[*Node]*Node
+	owned  map[*Node]bool
+}
+
+// NewCow creates a copy-on-write handle over the base tree.
+func NewCow(base Tree) *Cow {
+	n := base.(*Node)
+	return &Cow{
+		base:   n,
+		root:   n,
+		copies: make(map[*Node]*Node),
+		owned:  make(map[*Node]bool),
+	}
+}
+
+// Tree returns the root of the current view, it is the base tree until the first change.
+func (c *Cow) Tree() Tree {
+	return c.root
+}
+
+// Edit returns a private copy of the Node that is safe to modify.
+// The Node may belong to the base tree or be a copy returned earlier.
+// Children of the copy are still shared with the base tree,
+// so they have to be passed to Edit as well before being changed.
+func (c *Cow) Edit(n *Node) *Node {
+	if c.owned[n] {
+		return n
+	}
+	if cp, ok := c.copies[n]; ok {
+		return cp
+	}
+	cp := &Node{
+		Meta:        n.Meta,
+		Value:       n.Value,
+		status:      n.status,
+		description: n.description,
+		Nodes:       append([]*Node(nil), n.children()...),
+	}
+	if n.options != nil {
+		copied := *n.options
+		cp.options = &copied
+	}
+	if n == c.base || n.Root == nil {
+		c.root = cp
+	} else {
+		parent := c.Edit(n.Root)
+		if i := parent.indexOf(n); i >= 0 {
+			parent.Nodes[i] = cp
+			cp.index = i
+		}
+		cp.Root = parent
+	}
+	c.copies[n] = cp
+	c.owned[cp] = true
+	return cp
+}
+
+// Parent returns the parent of the Node in the current view, nil for the root of the view.
+func (c *Cow) Parent(n *Node) *Node {
+	if n == nil || n == c.root || n.Root == nil {
+		return nil
+	}
+	if cp, ok := c.copies[n.Root]; ok {
+		return cp
+	}
+	return n.Root
+}
+
+// Ancestors returns the ancestors of the Node in the current view, from its parent up to the root.
+func (c *Cow) Ancestors(n *Node) []*Node {
+	var ancestors []*Node
+	for node := c.Parent(n); node != nil; node = c.Parent(node) {
+		ancestors = append(ancestors, node)
+	}
+	return ancestors
+}
+
+// Index returns the position of the Node among the children of its parent in the current view,
+// or -1 for the root of the view.
+func (c *Cow) Index(n *Node) int {
+	parent := c.Parent(n)
+	if parent == nil {
+		return -1
+	}
+	return parent.indexOf(n)
+}
+
+// Prune removes the nodes matching fn from the current view,
+// copying only the branches whose children change.
+func (c *Cow) Prune(fn PruneFunc) {
+	stack := []*Node{c.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		temp := make([]*Node, 0, len(n.Nodes))
+		for _, node := range n.Nodes {
+			if fn(node) {
+				continue
+			}
+			temp = append(temp, node)
+		}
+		if len(temp) != len(n.Nodes) {
+			n = c.Edit(n)
+			n.Nodes = temp
+		}
+		for i := len(temp) - 1; i >= 0; i-- {
+			if len(temp[i].Nodes) > 0 {
+				stack = append(stack, temp[i])
+			}
+		}
+	}
+}
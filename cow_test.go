@@ -0,0 +1,121 @@
+package treeprint
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCow(t *testing.T) {
+	assert := assert.New(t)
+
+	base := New()
+	base.AddBranch("one").AddNode("a").AddNode("b")
+	base.AddBranch("two").AddNode("c\nd").AddNode("e")
+	expected := base.String()
+
+	pruned := NewCow(base)
+	pruned.Prune(func(item *Node) bool {
+		return item.Value == "b" || item.Value == "e"
+	})
+	assert.Equal(`.
+├── one
+│   └── a
+└── two
+    └── c
+        d
+`, pruned.Tree().String())
+	assert.Equal(expected, base.String())
+
+	edited := NewCow(base)
+	one := base.FindByValue("one").(*Node)
+	edited.Edit(one).SetValue("uno")
+	edited.Edit(one).AddNode("z")
+	assert.Equal(`.
+├── uno
+│   ├── a
+│   ├── b
+│   └── z
+└── two
+    ├── c
+    │   d
+    └── e
+`, edited.Tree().String())
+	assert.Equal(expected, base.String())
+
+	root := edited.Tree().(*Node)
+	assert.Same(base.(*Node).Nodes[1], root.Nodes[1])
+	assert.NotSame(one, root.Nodes[0])
+}
+
+func TestCowOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	base := New(WithEdgeStyle(ASCIIEdgeStyle))
+	base.AddBranch("one").AddNode("a")
+
+	edited := NewCow(base)
+	edited.Edit(base.FindByValue("a").(*Node)).SetValue("b")
+	assert.Equal(".\n`-- one\n    `-- b\n", edited.Tree().String(), "the copy of the root keeps the options of the tree")
+	assert.Equal(".\n`-- one\n    `-- a\n", base.String())
+}
+
+func TestCowConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int32
+	base := New().(*Node)
+	for _, dir := range []string{"a", "b", "c"} {
+		dir := dir
+		base.AddBranch(dir).(*Node).SetChildrenFunc(func() []*Node {
+			atomic.AddInt32(&calls, 1)
+			return []*Node{{Value: dir + "1"}, {Value: dir + "2"}}
+		})
+	}
+	pf := NewPrinter(WithValueCache(NewValueCache()))
+
+	var wg sync.WaitGroup
+	views := make([]string, 8)
+	for i := range views {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			view := NewCow(base)
+			view.Edit(base.Nodes[1]).SetValue(i)
+			views[i] = string(view.Tree().Bytes(pf))
+			base.Bytes(pf)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(int32(3), calls, "the lazy children of the shared nodes are produced once")
+	for i, s := range views {
+		assert.Contains(s, fmt.Sprintf("├── %d\n│   ├── b1", i))
+	}
+}
+
+func TestCowAncestors(t *testing.T) {
+	assert := assert.New(t)
+
+	base := New().(*Node)
+	one := base.AddBranch("one").(*Node)
+	a := one.AddBranch("a").(*Node)
+	b := one.AddNode("b").FindLastNode().(*Node)
+
+	view := NewCow(base)
+	uno := view.Edit(one)
+	uno.SetValue("uno")
+	root := view.Tree().(*Node)
+	assert.Same(uno, view.Parent(a))
+	assert.Equal([]*Node{uno, root}, view.Ancestors(a))
+	assert.Equal([]*Node{one, base}, a.Ancestors(), "the shared nodes keep their base ancestors")
+	assert.Equal(1, view.Index(b))
+	assert.Equal(-1, view.Index(root))
+	assert.Nil(view.Parent(root))
+
+	view.Prune(func(item *Node) bool { return item == a })
+	assert.Equal(0, view.Index(b))
+	assert.Equal(1, b.Index())
+}
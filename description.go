@@ -0,0 +1,57 @@
+package treeprint
+
+import "io"
+
+// SetDescription sets the secondary text of the Node, rendered on its own lines
+// under the meta and value, padded as the extra lines of a multiline value.
+// It saves formatting explanations into the value by hand. An empty d removes it.
+func (n *Node) SetDescription(d string) {
+	if n == nil {
+		return
+	}
+	n.description = d
+	n.MarkDirty()
+}
+
+// Description returns the secondary text of the Node, empty unless set.
+func (n *Node) Description() string {
+	if n == nil {
+		return ""
+	}
+	return n.description
+}
+
+// WithDescriptionStyle renders the descriptions with the given Style, as Dim to set
+// them apart from the values in terminals. They are rendered without one by default.
+func WithDescriptionStyle(s Style) Option {
+	return func(p *PrinterOptions) {
+		p.descriptionStyle = s
+	}
+}
+
+// appendDescription appends the lines of the description of a Node at the level,
+// -1 being the level of the Node the rendering starts from when it is a root.
+func (p *printer) appendDescription(line []byte, level int, n *Node) []byte {
+	if n.description == "" {
+		return line
+	}
+	p.described = append(p.described[:0], n.description...)
+	d := p.described
+	if p.pf.controlChars != KeepControlChars && hasControl(d) {
+		d = p.pf.appendControl(nil, d)
+	}
+	if p.pf.descriptionStyle != "" {
+		d = p.pf.descriptionStyle.appendStyled(nil, d)
+	}
+	line = append(line, '\n')
+	line = appendPrefix(p, line, level+1)
+	return appendValue(p, line, level, d)
+}
+
+// printDescription writes the description of a Node printed by printNode.
+func (p PrinterOptions) printDescription(n *Node, w io.Writer) {
+	if n.description != "" {
+		io.WriteString(w, "\n")
+		io.WriteString(w, n.description)
+	}
+}
@@ -0,0 +1,41 @@
+package treeprint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescription(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewWithRoot("app")
+	tree.SetDescription("the application")
+	build := tree.AddBranch("build")
+	build.SetDescription("compiles the sources\nand links them")
+	build.AddMetaNode("1s", "compile")
+	tree.FindByValue("compile").SetDescription("go build")
+	tree.AddNode("test")
+	tree.FindByValue("test").SetDescription("runs the tests\x07")
+
+	assert.Equal("compiles the sources\nand links them", build.Description())
+	assert.Equal(`app
+the application
+├── build
+│   compiles the sources
+│   and links them
+│   └── [1s]  compile
+│       go build
+└── test
+    runs the tests`+"\a\n", tree.String())
+
+	f := NewPrinter(WithDescriptionStyle(Dim), WithControlChars(EscapeControlChars))
+	assert.Equal("└── test\n    \x1b[2mruns the tests\\a\x1b[0m\n", string(tree.FindByValue("test").Bytes(f)))
+	assert.Equal(tree.String(), copyTree(tree.(*Node)).String())
+	var buf bytes.Buffer
+	_, err := NewIncrementalPrinter().Render(&buf, tree.(*Node))
+	assert.NoError(err)
+	assert.Equal(tree.String(), buf.String())
+	assert.Empty((*Node)(nil).Description())
+}
@@ -0,0 +1,197 @@
+package treeprint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ououmania/treeprint/internal/lcs"
+)
+
+// DiffReporter renders the differences between two trees as a tree, every line
+// starting with "-" when only in the wanted tree, "+" when only in the got one,
+// or a space when in both, as go-cmp does. It is meant for test failure messages:
+//
+//	if diff := treeprint.Diff(want, got); diff != "" {
+//		t.Errorf("tree mismatch (-want +got):\n%s", diff)
+//	}
+//
+// The children of matching nodes are paired by their rendered line, keeping
+// their order, and the unpaired ones are shown with their whole subtree.
+type DiffReporter struct {
+	// Color highlights the removed lines in red and the added ones in green with ANSI colors.
+	Color bool
+}
+
+// Diff renders the differences between two trees with the zero DiffReporter,
+// it returns an empty string for equal trees.
+func Diff(want, got Tree) string {
+	return DiffReporter{}.Diff(want, got)
+}
+
+// Diff renders the differences between two trees, it returns an empty string for equal trees.
+// A nil Tree is an empty one, the other tree is then shown whole as removed or added.
+func (r DiffReporter) Diff(want, got Tree) string {
+	w, _ := want.(*Node)
+	g, _ := got.(*Node)
+	if w == nil && g == nil {
+		return ""
+	}
+	var b strings.Builder
+	changed := false
+	line := func(status byte, prefix, text string) {
+		if status != ' ' {
+			changed = true
+		}
+		switch {
+		case r.Color && status == '-':
+			fmt.Fprintf(&b, "\x1b[31m%c %s%s\x1b[0m\n", status, prefix, text)
+		case r.Color && status == '+':
+			fmt.Fprintf(&b, "\x1b[32m%c %s%s\x1b[0m\n", status, prefix, text)
+		default:
+			fmt.Fprintf(&b, "%c %s%s\n", status, prefix, text)
+		}
+	}
+	// node writes the lines of a Node, the extra lines of a multiline value with the next prefix
+	node := func(status byte, n *Node, prefix, next string) {
+		for i, l := range strings.Split(diffText(n), "\n") {
+			if i > 0 {
+				prefix = next
+			}
+			line(status, prefix, l)
+		}
+	}
+
+	root := diffEntry{status: ' ', want: w, got: g}
+	switch {
+	case g == nil:
+		root.status = '-'
+		node('-', w, "", "")
+	case w == nil:
+		root.status = '+'
+		node('+', g, "", "")
+	case diffText(w) != diffText(g):
+		node('-', w, "", "")
+		node('+', g, "", "")
+	default:
+		node(' ', w, "", "")
+	}
+	type frame struct {
+		entries []diffEntry
+		i       int
+		prefix  string
+		parent  diffEntry
+	}
+	// the tree is drawn as the wanted one is rendered
+	pf := root.node().Options()
+	edges := pf.edgeStyle()
+	link, blank := pf.segments()
+	stack := []frame{{entries: root.children(), parent: root}}
+	// an entry reached again from one of its own descendants is shown without its children
+	path := map[diffEntry]bool{root: true}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.entries) {
+			delete(path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		e := top.entries[top.i]
+		top.i++
+		edge, next := edges.Mid, top.prefix+link
+		if top.i == len(top.entries) {
+			edge, next = edges.End, top.prefix+blank
+		}
+		node(e.status, e.node(), top.prefix+string(edge)+" ", next)
+		if path[e] {
+			continue
+		}
+		if children := e.children(); len(children) > 0 {
+			path[e] = true
+			stack = append(stack, frame{entries: children, prefix: next, parent: e})
+		}
+	}
+	if !changed {
+		return ""
+	}
+	return b.String()
+}
+
+// DiffText is like Diff for two trees rendered with the default printer options, see Parse.
+func (r DiffReporter) DiffText(want, got string) (string, error) {
+	w, err := ParseString(want)
+	if err != nil {
+		return "", err
+	}
+	g, err := ParseString(got)
+	if err != nil {
+		return "", err
+	}
+	return r.Diff(w, g), nil
+}
+
+// diffEntry is a Node of the diff, either matching in both trees, or in one only.
+type diffEntry struct {
+	status byte
+	want   *Node
+	got    *Node
+}
+
+func (e diffEntry) node() *Node {
+	if e.want != nil {
+		return e.want
+	}
+	return e.got
+}
+
+// children returns the entries of the children, the unpaired nodes keeping their status
+// for their whole subtree.
+func (e diffEntry) children() []diffEntry {
+	switch e.status {
+	case '-':
+		return statusEntries('-', e.want.children(), true)
+	case '+':
+		return statusEntries('+', e.got.children(), false)
+	}
+	w, g := e.want.children(), e.got.children()
+	// the lines are formatted once, not for every pair compared
+	wt, gt := make([]string, len(w)), make([]string, len(g))
+	for i, n := range w {
+		wt[i] = diffText(n)
+	}
+	for j, n := range g {
+		gt[j] = diffText(n)
+	}
+	ops := lcs.Diff(len(w), len(g), func(i, j int) bool { return wt[i] == gt[j] })
+	entries := make([]diffEntry, len(ops))
+	for k, op := range ops {
+		switch op.Kind {
+		case ' ':
+			entries[k] = diffEntry{status: ' ', want: w[op.A], got: g[op.B]}
+		case '-':
+			entries[k] = diffEntry{status: '-', want: w[op.A]}
+		default:
+			entries[k] = diffEntry{status: '+', got: g[op.B]}
+		}
+	}
+	return entries
+}
+
+func statusEntries(status byte, nodes []*Node, want bool) []diffEntry {
+	entries := make([]diffEntry, len(nodes))
+	for i, n := range nodes {
+		entries[i].status = status
+		if want {
+			entries[i].want = n
+		} else {
+			entries[i].got = n
+		}
+	}
+	return entries
+}
+
+// diffText returns the text of a Node as the default printer renders it.
+func diffText(n *Node) string {
+	var b strings.Builder
+	NewPrinter().printNode(n, &b)
+	return b.String()
+}
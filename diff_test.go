@@ -0,0 +1,67 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	want := New()
+	one := want.AddBranch("one")
+	one.AddNode("a")
+	one.AddBranch("b").AddNode("c")
+	want.AddNode("two")
+
+	got := New()
+	one = got.AddBranch("one")
+	one.AddNode("a\nmulti")
+	one.AddBranch("b").AddNode("c")
+	got.AddBranch("three").AddNode("d")
+	got.AddNode("two")
+
+	assert.Empty(Diff(want, want))
+	assert.Equal(`  .
+  ├── one
+- │   ├── a
++ │   ├── a
++ │   │   multi
+  │   └── b
+  │       └── c
++ ├── three
++ │   └── d
+  └── two
+`, Diff(want, got))
+
+	assert.Equal("- x\n+ y\n", Diff(NewWithRoot("x"), NewWithRoot("y")))
+	assert.Equal("  .\n\x1b[32m+ └── [m]  a\x1b[0m\n", DiffReporter{Color: true}.Diff(New(), func() Tree {
+		t := New()
+		t.AddMetaNode("m", "a")
+		return t
+	}()))
+
+	diff, err := DiffReporter{}.DiffText(want.String(), got.String())
+	assert.NoError(err)
+	assert.Equal(Diff(want, got), diff)
+	_, err = DiffReporter{}.DiffText("", "")
+	assert.ErrorIs(err, ErrSyntax)
+
+	var none *Node
+	assert.Empty(Diff(nil, nil))
+	assert.Equal("+ .\n+ ├── one\n+ │   ├── a\n+ │   └── b\n+ │       └── c\n+ └── two\n", Diff(nil, want))
+	assert.Equal("- .\n- └── x\n", Diff(func() Tree {
+		t := New()
+		t.AddNode("x")
+		return t
+	}(), none))
+
+	ascii := New(WithEdgeStyle(ASCIIEdgeStyle), WithIndentSize(1))
+	ascii.AddBranch("one").AddNode("a")
+	assert.Equal("  .\n  `-- one\n-   `-- a\n", Diff(ascii, func() Tree {
+		t := New()
+		t.AddBranch("one")
+		return t
+	}()))
+}
@@ -0,0 +1,39 @@
+package treeprint
+
+import "fmt"
+
+// FromError builds the tree of the Unwrap chain of err: every error is a Node
+// with its message as the value and its type as the meta, the errors it wraps
+// being its children. Errors wrapping several ones, as errors.Join does, become branches.
+// A nil error gives a lone "<nil>" root.
+func FromError(err error) Tree {
+	if err == nil {
+		return NewWithRoot("<nil>")
+	}
+	root := &Node{Meta: fmt.Sprintf("%T", err), Value: err.Error()}
+	type entry struct {
+		err  error
+		node *Node
+	}
+	stack := []entry{{err: err, node: root}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		var wrapped []error
+		switch u := e.err.(type) {
+		case interface{ Unwrap() error }:
+			wrapped = []error{u.Unwrap()}
+		case interface{ Unwrap() []error }:
+			wrapped = u.Unwrap()
+		}
+		for _, w := range wrapped {
+			if w == nil {
+				continue
+			}
+			child := e.node.newChild(fmt.Sprintf("%T", w), w.Error())
+			e.node.Nodes = append(e.node.Nodes, child)
+			stack = append(stack, entry{err: w, node: child})
+		}
+	}
+	return root
+}
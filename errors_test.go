@@ -0,0 +1,32 @@
+package treeprint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type joinedError []error
+
+func (e joinedError) Error() string   { return "joined" }
+func (e joinedError) Unwrap() []error { return e }
+
+func TestFromError(t *testing.T) {
+	assert := assert.New(t)
+
+	pathErr := &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+	err := fmt.Errorf("load: %w", joinedError{pathErr, io.EOF, nil})
+	assert.Equal(`[*fmt.wrapError]  load: joined
+└── [treeprint.joinedError]  joined
+    ├── [*fs.PathError]  open config.yaml: file does not exist
+    │   └── [*errors.errorString]  file does not exist
+    └── [*errors.errorString]  EOF
+`, FromError(err).String())
+
+	assert.Equal("[*errors.errorString]  plain\n", FromError(errors.New("plain")).String())
+	assert.Equal("<nil>\n", FromError(nil).String())
+}
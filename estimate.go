@@ -0,0 +1,112 @@
+package treeprint
+
+import "strings"
+
+// estimatedValueSize is the size assumed for values that are neither strings nor byte slices.
+const estimatedValueSize = 16
+
+// EstimateSize estimates the size in bytes of the tree or subtree rendered with
+// the given printer options, without running the print funcs. The sizes of string
+// values and metas are exact, HTML escaping included, for the other types a fixed
+// size is assumed. Level prefixes are counted as link edges, which makes the
+// estimate of the tree structure an upper bound. The line endings and the fence
+// are counted, the transformer is not.
+// The estimate stops at the depth, node and byte limits of the options as rendering does,
+// and the lazy children not produced yet are neither produced nor counted.
+// It is meant for presizing buffers and rejecting outputs that would be too large
+// before rendering them.
+func (n *Node) EstimateSize(f PrinterOptions) int64 {
+	if n == nil {
+		return 0
+	}
+	style := f.edgeStyle()
+	link := int64(len(style.Link) + f.indentSize())
+	edge := int64(len(style.Mid))
+	if end := int64(len(style.End)); end > edge {
+		edge = end
+	}
+
+	eol := int64(len(f.lineEnd()))
+	size := estimateNode(n, f, 0, link, eol)
+	if n.Root != nil {
+		size += edge + 1
+	}
+	var count int
+	// only the materialized children are walked, with the cycles cut as walkNodes does
+	stack := []walkFrame{{parent: n, depth: 1}}
+	path := map[*Node]bool{n: true}
+	for len(stack) > 0 {
+		if f.maxBytes > 0 && size >= int64(f.maxBytes) {
+			return int64(f.maxBytes) + estimateFence(f, eol)
+		}
+		top := &stack[len(stack)-1]
+		nodes := top.parent.materialized()
+		if top.i == len(nodes) {
+			delete(path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if f.maxNodes > 0 && count >= f.maxNodes {
+			break
+		}
+		item, depth := nodes[top.i], top.depth
+		top.i++
+		count++
+		// depth 1 is rendered at level 0
+		level := int64(depth - 1)
+		size += level*link + edge + 1 + estimateNode(item, f, level, link, eol)
+		if (f.maxDepth <= 0 || depth < f.maxDepth) && !path[item] && len(item.materialized()) > 0 {
+			path[item] = true
+			stack = append(stack, walkFrame{parent: item, depth: depth + 1})
+		}
+	}
+	if f.maxBytes > 0 && size > int64(f.maxBytes) {
+		size = int64(f.maxBytes)
+	}
+	// the fence is not counted by the byte limit
+	return size + estimateFence(f, eol)
+}
+
+// estimateFence returns the size of the fence markup, with its line endings.
+func estimateFence(f PrinterOptions, eol int64) int64 {
+	if f.fence == nil {
+		return 0
+	}
+	markup := f.fence.open() + f.fence.close()
+	return int64(len(markup)) + int64(strings.Count(markup, "\n"))*(eol-1)
+}
+
+// estimateNode estimates the size of the meta and value of a Node, including the line break
+// and, for multiline values, the padding and the line endings of the extra lines.
+func estimateNode(n *Node, f PrinterOptions, level, link, eol int64) int64 {
+	escaped := f.fence != nil && f.fence.html
+	var size int64
+	if n.Meta != nil && f.metaFuncAt(int(level)+1) != nil {
+		// brackets and the separator of the default meta format
+		size += estimateValue(n.Meta, escaped) + 4
+	}
+	if f.valuePrint != nil {
+		size += estimateValue(n.Value, escaped)
+		if v, ok := n.Value.(string); ok {
+			size += int64(strings.Count(v, "\n")) * ((level+1)*link + eol - 1)
+		}
+	}
+	if n.description != "" {
+		size += estimateValue(n.description, escaped) + int64(strings.Count(n.description, "\n")+1)*((level+1)*link+eol)
+	}
+	return size + eol
+}
+
+func estimateValue(v interface{}, escaped bool) int64 {
+	s, ok := v.(string)
+	if !ok {
+		return estimatedValueSize
+	}
+	size := int64(len(s))
+	if escaped {
+		// the growth of the characters html.EscapeString replaces
+		size += 3*int64(strings.Count(s, "<")+strings.Count(s, ">")) +
+			4*int64(strings.Count(s, "&")+strings.Count(s, "'")+strings.Count(s, "\""))
+	}
+	return size
+}
@@ -0,0 +1,83 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateSize(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddNode("a")
+	tree.AddMetaBranch("meta", "one").AddNode("b\nc")
+	f := NewPrinter()
+
+	// only the structure is overestimated, the values are all strings
+	actual := len(tree.String())
+	estimate := tree.EstimateSize(f)
+	assert.GreaterOrEqual(estimate, int64(actual))
+	assert.LessOrEqual(estimate, int64(actual+2*len(EdgeTypeLink)))
+
+	sub := tree.FindByMeta("meta")
+	assert.GreaterOrEqual(sub.EstimateSize(f), int64(len(sub.String())))
+
+	limited := NewPrinter(WithMaxDepth(1))
+	assert.GreaterOrEqual(tree.EstimateSize(limited), int64(len(tree.Bytes(limited))))
+	assert.Less(tree.EstimateSize(limited), estimate)
+
+	numbers := New()
+	numbers.AddNode(1).AddNode(2)
+	assert.Equal(int64(2+2*(len(EdgeTypeMid)+2+estimatedValueSize)), numbers.EstimateSize(f))
+	assert.Equal(int64(2+len(EdgeTypeMid)+2+estimatedValueSize), numbers.EstimateSize(NewPrinter(WithMaxNodes(1))))
+	assert.Equal(int64(10), numbers.EstimateSize(NewPrinter(WithMaxBytes(10))))
+}
+
+func TestEstimateSizeOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddNode("<a> & 'b'")
+	tree.AddMetaBranch("meta", "one").AddNode("b\nc")
+	for _, f := range []PrinterOptions{
+		NewPrinter(WithLineEnding("\r\n")),
+		NewPrinter(WithCodeFence("text", "caption")),
+		NewPrinter(WithHTMLPre("text", "caption"), WithLineEnding("\r\n")),
+	} {
+		// as for the default options, only the structure is overestimated
+		actual := len(tree.Bytes(f))
+		estimate := tree.EstimateSize(f)
+		assert.GreaterOrEqual(estimate, int64(actual))
+		assert.LessOrEqual(estimate, int64(actual+2*len(EdgeTypeLink)))
+	}
+	// the fence is not counted by the byte limit
+	assert.Equal(int64(10+len("<pre></pre>\n")), tree.EstimateSize(NewPrinter(WithHTMLPre("", ""), WithMaxBytes(10))))
+}
+
+func TestEstimateSizeLazy(t *testing.T) {
+	assert := assert.New(t)
+
+	// every Node has ten more children, the tree is unbounded
+	var produced int
+	var grow func(n *Node)
+	grow = func(n *Node) {
+		n.SetChildrenFunc(func() []*Node {
+			nodes := make([]*Node, 10)
+			for i := range nodes {
+				nodes[i] = &Node{Value: "child"}
+				grow(nodes[i])
+			}
+			produced += len(nodes)
+			return nodes
+		})
+	}
+	lazy := New().(*Node)
+	grow(lazy)
+
+	assert.Equal(int64(2), lazy.EstimateSize(NewPrinter()), "the lazy children are not produced")
+	assert.Zero(produced)
+	b := lazy.Bytes(NewPrinter(WithMaxBytes(100)))
+	assert.Contains(string(b), "byte limit reached")
+	assert.Less(produced, 1000)
+}
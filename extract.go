@@ -0,0 +1,51 @@
+package treeprint
+
+// ExtractFunc function type for selecting the nodes kept by Extract.
+type ExtractFunc func(item *Node) bool
+
+// Extract returns a new tree holding copies of the descendants matched by fn along
+// with their ancestors, so that they keep their context, as in views showing only
+// the failing tests or the changed files. The root of the new tree is a copy of n,
+// created with the printer options of its tree. The descendants of the matching nodes
+// are not kept unless they match as well. A Node reached again, through a cycle, is
+// only copied where it was first met. The original tree is left unchanged.
+func (n *Node) Extract(fn ExtractFunc) Tree {
+	if n == nil {
+		return n
+	}
+	root := &Node{Meta: n.Meta, Value: n.Value, status: n.status, description: n.description}
+	if options := n.root().options; options != nil {
+		copied := *options
+		root.options = &copied
+	}
+	parents := map[*Node]*Node{n: nil}
+	var order []*Node
+	keep := map[*Node]bool{n: true}
+	walkNodes(n, func(item *Node, _ int, parent *Node) WalkAction {
+		if _, seen := parents[item]; seen {
+			return WalkSkipChildren
+		}
+		parents[item] = parent
+		order = append(order, item)
+		if fn(item) {
+			// the ancestors up to the first one already kept
+			for node := item; !keep[node]; node = parents[node] {
+				keep[node] = true
+			}
+		}
+		return WalkContinue
+	})
+	copies := map[*Node]*Node{n: root}
+	for _, item := range order {
+		if !keep[item] {
+			continue
+		}
+		parent := copies[parents[item]]
+		child := parent.newChild(item.Meta, item.Value)
+		child.status = item.status
+		child.description = item.description
+		parent.Nodes = append(parent.Nodes, child)
+		copies[item] = child
+	}
+	return root
+}
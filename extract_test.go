@@ -0,0 +1,64 @@
+package treeprint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtract(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewWithRoot("tests", WithEdgeStyle(ASCIIEdgeStyle))
+	pkg := tree.AddBranch("pkg/a")
+	pkg.AddMetaNode("ok", "TestOne")
+	sub := pkg.AddMetaBranch("fail", "TestTwo")
+	sub.AddMetaNode("ok", "case 1")
+	sub.AddMetaNode("fail", "case 2")
+	tree.AddBranch("pkg/b").AddMetaNode("ok", "TestThree")
+	tree.AddMetaNode("fail", "TestFour")
+	before := tree.String()
+
+	failing := tree.Extract(func(item *Node) bool {
+		return item.Meta == "fail"
+	})
+	assert.Equal(`tests
+|-- pkg/a
+|   `+"`"+`-- [fail]  TestTwo
+|       `+"`"+`-- [fail]  case 2
+`+"`"+`-- [fail]  TestFour
+`, failing.String())
+	assert.NoError(failing.Validate())
+	assert.Equal(before, tree.String(), "the original tree is unchanged")
+
+	none := tree.FindByValue("pkg/b").Extract(func(item *Node) bool {
+		return strings.HasPrefix(valueString(item), "x")
+	})
+	assert.Equal("pkg/b\n", none.String())
+}
+
+func TestExtractCycle(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New().(*Node)
+	a := tree.AddBranch("a").(*Node)
+	b := a.AddBranch("b").(*Node)
+	b.AddNode("c")
+	// bypass AddTree, which refuses to create cycles
+	b.Nodes = append(b.Nodes, a)
+	a.Nodes = append(a.Nodes, tree)
+
+	var extracted Tree
+	assert.NotPanics(func() {
+		extracted = tree.Extract(func(item *Node) bool {
+			return item.Value == "a" || item.Value == "c"
+		})
+	})
+	assert.Equal(`.
+└── a
+    └── b
+        └── c
+`, extracted.String())
+	assert.NoError(extracted.Validate())
+}
@@ -0,0 +1,89 @@
+package treeprint
+
+import (
+	"html"
+	"io"
+)
+
+// fence wraps the output for documents, see WithCodeFence and WithHTMLPre.
+type fence struct {
+	html    bool
+	lang    string
+	caption string
+}
+
+// WithCodeFence wraps the output in a Markdown code fence tagged with the language,
+// if any, and preceded by the caption line, if any. The fence is not counted by WithMaxBytes.
+func WithCodeFence(lang, caption string) Option {
+	return func(p *PrinterOptions) {
+		p.fence = &fence{lang: lang, caption: caption}
+	}
+}
+
+// WithHTMLPre wraps the output, HTML escaped, in a <pre> element, holding a <code> element
+// with a "language-" class when the language is not empty. With a caption, the <pre> element
+// is wrapped in a <figure> element along with a <figcaption>.
+func WithHTMLPre(lang, caption string) Option {
+	return func(p *PrinterOptions) {
+		p.fence = &fence{html: true, lang: lang, caption: caption}
+	}
+}
+
+// openFence writes the beginning of the fence, if any.
+func (p *printer) openFence() {
+	if f := p.pf.fence; f != nil {
+		p.writeMarkup(f.open())
+		p.escapeHTML = f.html
+	}
+}
+
+// closeFence writes the end of the fence, if any.
+func (p *printer) closeFence() {
+	if f := p.pf.fence; f != nil {
+		p.escapeHTML = false
+		p.writeMarkup(f.close())
+	}
+}
+
+// open returns the markup written before the output.
+func (f *fence) open() string {
+	if !f.html {
+		s := "```" + f.lang + "\n"
+		if f.caption != "" {
+			s = f.caption + "\n\n" + s
+		}
+		return s
+	}
+	s := "<pre>"
+	if f.caption != "" {
+		s = "<figure>\n<figcaption>" + html.EscapeString(f.caption) + "</figcaption>\n" + s
+	}
+	if f.lang != "" {
+		s += `<code class="language-` + html.EscapeString(f.lang) + `">`
+	}
+	return s
+}
+
+// close returns the markup written after the output.
+func (f *fence) close() string {
+	if !f.html {
+		return "```\n"
+	}
+	s := "</pre>\n"
+	if f.lang != "" {
+		s = "</code>" + s
+	}
+	if f.caption != "" {
+		s += "</figure>\n"
+	}
+	return s
+}
+
+// writeMarkup writes the fence, which is not counted by the byte limit.
+func (p *printer) writeMarkup(s string) {
+	unlimited, n := p.unlimited, p.n
+	p.unlimited = true
+	io.WriteString(p, s)
+	p.unlimited = unlimited
+	p.markup += p.n - n
+}
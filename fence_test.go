@@ -0,0 +1,45 @@
+package treeprint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeFence(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("<one>").AddNode("a & b")
+	tree.AddNode("two")
+
+	assert.Equal("```\n"+tree.String()+"```\n", string(tree.Bytes(NewPrinter(WithCodeFence("", "")))))
+	assert.Equal("Layout:\n\n```text\n"+tree.String()+"```", tree.Print(NewPrinter(WithCodeFence("text", "Layout:"))))
+
+	expected := `<pre>.
+├── &lt;one&gt;
+│   └── a &amp; b
+└── two
+</pre>
+`
+	assert.Equal(expected, string(tree.Bytes(NewPrinter(WithHTMLPre("", "")))))
+	buf := new(bytes.Buffer)
+	n, err := tree.PrintToParallel(buf, NewPrinter(WithHTMLPre("", "")), 2)
+	assert.NoError(err)
+	assert.Equal(expected, buf.String())
+	assert.Equal(int64(len(expected)), n)
+
+	assert.Equal(`<figure>
+<figcaption>A &lt;tree&gt;</figcaption>
+<pre><code class="language-text">.
+├── &lt;one&gt;
+│   └── a &amp; b
+└── two
+</code></pre>
+</figure>
+`, string(tree.Bytes(NewPrinter(WithHTMLPre("text", "A <tree>")))))
+
+	assert.Equal("```\n.\n├── <one>\n… output truncated (byte limit reached)\n```\n",
+		string(tree.Bytes(NewPrinter(WithCodeFence("", ""), WithMaxBytes(20)))))
+}
@@ -0,0 +1,103 @@
+package treeprint
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// Matcher decides which nodes a search finds, a Node matches when both its value and its meta match.
+type Matcher interface {
+	// MatchValue reports whether the value of a Node matches.
+	MatchValue(value Value) bool
+	// MatchMeta reports whether the meta value of a Node matches.
+	MatchMeta(meta MetaValue) bool
+}
+
+// Find finds the first Node, in depth-first order, matched by m, returns nil if not found.
+func (n *Node) Find(m Matcher) Tree {
+	if node := n.find(func(node *Node) bool {
+		return m.MatchValue(node.Value) && m.MatchMeta(node.Meta)
+	}); node != nil {
+		return node
+	}
+	return nil
+}
+
+// find returns the first descendant of n, in depth-first order, for which fn reports true.
+func (n *Node) find(fn func(*Node) bool) *Node {
+	if n == nil {
+		return nil
+	}
+	// the lazy children are not produced, the cycles are cut as walkNodes does
+	stack := []walkFrame{{parent: n}}
+	path := map[*Node]bool{n: true}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.parent.Nodes) {
+			delete(path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.parent.Nodes[top.i]
+		top.i++
+		if fn(node) {
+			return node
+		}
+		if !path[node] && len(node.Nodes) > 0 {
+			path[node] = true
+			stack = append(stack, walkFrame{parent: node})
+		}
+	}
+	return nil
+}
+
+type valueMatcher func(v interface{}) bool
+
+func (m valueMatcher) MatchValue(value Value) bool   { return m(value) }
+func (m valueMatcher) MatchMeta(meta MetaValue) bool { return true }
+
+type metaMatcher struct{ m Matcher }
+
+func (m metaMatcher) MatchValue(value Value) bool   { return true }
+func (m metaMatcher) MatchMeta(meta MetaValue) bool { return m.m.MatchValue(meta) }
+
+// Equal returns a Matcher matching the values equal to value by reflect.DeepEqual.
+func Equal(value Value) Matcher {
+	return valueMatcher(func(v interface{}) bool {
+		return reflect.DeepEqual(v, value)
+	})
+}
+
+// Contains returns a Matcher matching the values whose %v formatting contains substr.
+func Contains(substr string) Matcher {
+	return containsMatcher(substr)
+}
+
+// MatchRegexp returns a Matcher matching the values whose %v formatting matches re.
+func MatchRegexp(re *regexp.Regexp) Matcher {
+	return regexpMatcher{re: re}
+}
+
+// TypeOf returns a Matcher matching the values of type T,
+// or implementing T when it is an interface type.
+func TypeOf[T any]() Matcher {
+	return valueMatcher(func(v interface{}) bool {
+		_, ok := v.(T)
+		return ok
+	})
+}
+
+// OnMeta returns a Matcher applying the value matching of m to the meta values instead,
+// the values of the nodes are not checked.
+func OnMeta(m Matcher) Matcher {
+	return metaMatcher{m: m}
+}
+
+// sprint formats v with %v, without going through fmt for strings.
+func sprint(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,40 @@
+package treeprint
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindByValueNested(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("one").AddBranch("two").AddMetaNode("meta", "three")
+
+	found := tree.FindByValue("three")
+	if assert.NotNil(found) {
+		assert.Equal("meta", found.(*Node).Meta)
+	}
+	assert.Nil(tree.FindByValue("meta"))
+	assert.NotNil(tree.FindByMeta("meta"))
+}
+
+func TestFind(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	src := tree.AddBranch("src")
+	src.AddMetaNode(120, "main.go")
+	src.AddMetaNode("generated", "zz_types.go")
+	tree.AddNode(42)
+
+	assert.Equal("main.go", tree.Find(Contains(".go")).(*Node).Value)
+	assert.Equal("zz_types.go", tree.Find(MatchRegexp(regexp.MustCompile(`^zz_`))).(*Node).Value)
+	assert.Equal(42, tree.Find(TypeOf[int]()).(*Node).Value)
+	assert.Equal("main.go", tree.Find(OnMeta(TypeOf[int]())).(*Node).Value)
+	assert.Equal("zz_types.go", tree.Find(OnMeta(Equal("generated"))).(*Node).Value)
+	assert.Nil(tree.Find(Contains("missing")))
+	assert.Nil(tree.Freeze().Find(Contains("missing")))
+}
@@ -0,0 +1,169 @@
+package treeprint
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// Forest is a sequence of trees, rendered one after the other with the same printer options.
+// It is for the data having several roots, which a single tree would only hold
+// below a made up root.
+type Forest []*Node
+
+// NewForest creates a Forest of the given trees.
+func NewForest(trees ...Tree) Forest {
+	f := make(Forest, 0, len(trees))
+	for _, t := range trees {
+		f = append(f, t.(*Node))
+	}
+	return f
+}
+
+// AddRoot adds a new tree with the given root value to the Forest and returns it.
+func (f *Forest) AddRoot(v Value) Tree {
+	return f.AddMetaRoot(nil, v)
+}
+
+// AddMetaRoot adds a new tree with the given root meta and value to the Forest and returns it.
+func (f *Forest) AddMetaRoot(meta MetaValue, v Value) Tree {
+	n := &Node{Meta: meta, Value: v}
+	*f = append(*f, n)
+	return n
+}
+
+// PrintTo renders the trees into w one after the other, each as PrintTo would.
+// The limits and the fence apply to every tree on its own.
+// It returns the number of bytes written and the first write error encountered.
+func (f Forest) PrintTo(w io.Writer, pf PrinterOptions) (int64, error) {
+	var total int64
+	for _, n := range f {
+		written, err := n.PrintTo(w, pf)
+		total += written
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// Bytes renders the trees as byteslice.
+func (f Forest) Bytes(pf PrinterOptions) []byte {
+	var buf bytes.Buffer
+	// writing into a bytes.Buffer never fails
+	_, _ = f.PrintTo(&buf, pf)
+	return buf.Bytes()
+}
+
+// Print renders the trees as a string.
+func (f Forest) Print(pf PrinterOptions) string {
+	return strings.Trim(string(f.Bytes(pf)), " \n")
+}
+
+// String renders the trees as a string with the default printer options.
+func (f Forest) String() string {
+	return string(f.Bytes(NewPrinter()))
+}
+
+// VisitAll calls fn for every Node of the Forest, the roots included, in depth-first pre-order.
+func (f Forest) VisitAll(fn NodeVisitor) {
+	for _, n := range f {
+		fn(n)
+		n.VisitAll(fn)
+	}
+}
+
+// Edge links a parent value to a child value, see FromEdges.
+type Edge struct {
+	Parent Value
+	Child  Value
+}
+
+// FromEdges builds the Forest of the given edges, where every distinct value becomes a single Node.
+// The values must be comparable. The roots are the values that are never a child,
+// in the order they first appear, and the children follow the order of the edges.
+// A value having several parents is only added below the first one, and a cycle
+// is broken by making its value appearing first a root. See WithMaxChildren for the options,
+// the values below a left out child are left out as well.
+func FromEdges(edges []Edge, options ...BuildOption) Forest {
+	b := newBuildOptions(options)
+	// dropped holds the values left out by WithMaxChildren
+	dropped := make(map[Value]bool)
+	var order []Value
+	nodes := make(map[Value]*Node)
+	node := func(v Value) *Node {
+		n, ok := nodes[v]
+		if !ok {
+			n = &Node{Value: v}
+			nodes[v] = n
+			order = append(order, v)
+		}
+		return n
+	}
+	for _, e := range edges {
+		if dropped[e.Parent] {
+			if n, ok := nodes[e.Child]; !ok || n.Root == nil {
+				dropped[e.Child] = true
+			}
+			continue
+		}
+		parent := node(e.Parent)
+		if dropped[e.Child] {
+			continue
+		}
+		if n, ok := nodes[e.Child]; (!ok || n.Root == nil && n != parent) && b.full(parent) {
+			b.omit(parent)
+			dropped[e.Child] = true
+			continue
+		}
+		child := node(e.Child)
+		if child.Root != nil || child == parent {
+			continue
+		}
+		child.Root = parent
+		child.index = len(parent.Nodes)
+		parent.Nodes = append(parent.Nodes, child)
+	}
+
+	var f Forest
+	reached := make(map[*Node]bool, len(nodes))
+	reach := func(n *Node) {
+		reached[n] = true
+		n.VisitAll(func(item *Node) {
+			reached[item] = true
+		})
+	}
+	// the subtrees built before their root got left out
+	for _, v := range order {
+		if n := nodes[v]; dropped[v] && !reached[n] {
+			reach(n)
+		}
+	}
+	for _, v := range order {
+		if n := nodes[v]; n.Root == nil && !reached[n] {
+			f = append(f, n)
+			reach(n)
+		}
+	}
+	// the nodes left over are in cycles
+	for _, v := range order {
+		if n := nodes[v]; !reached[n] {
+			n.detach()
+			f = append(f, n)
+			reach(n)
+		}
+	}
+	b.summarize()
+	return f
+}
+
+// FromPathsForest is like FromPaths, but makes the first elements of the paths
+// the roots of a Forest instead of children of a "." root.
+func FromPathsForest(paths []string, sep string, options ...BuildOption) Forest {
+	root := FromPaths(paths, sep, options...).(*Node)
+	f := Forest(root.Nodes)
+	for _, n := range f {
+		n.Root = nil
+	}
+	return f
+}
@@ -0,0 +1,77 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForest(t *testing.T) {
+	assert := assert.New(t)
+
+	var f Forest
+	f.AddRoot("a").AddNode("a1")
+	f.AddMetaRoot("m", "b").AddBranch("b1").AddNode("b2")
+	f = append(f, NewForest(NewWithRoot("c"))...)
+
+	assert.Equal("a\n└── a1\n[m]  b\n└── b1\n    └── b2\nc\n", f.String())
+	assert.Equal("a\n`-- a1\n[m]  b\n`-- b1\n    `-- b2\nc", f.Print(NewPrinter(WithEdgeStyle(ASCIIEdgeStyle))))
+
+	var values []Value
+	f.VisitAll(func(item *Node) {
+		values = append(values, item.Value)
+	})
+	assert.Equal([]Value{"a", "a1", "b", "b1", "b2", "c"}, values)
+}
+
+func TestFromEdges(t *testing.T) {
+	assert := assert.New(t)
+
+	f := FromEdges([]Edge{
+		{"eng", "backend"},
+		{"ops", "oncall"},
+		{"eng", "frontend"},
+		{"backend", "api"},
+		{"frontend", "api"},
+		{"x", "y"},
+		{"y", "x"},
+		{"z", "z"},
+	})
+	assert.Equal(`eng
+├── backend
+│   └── api
+└── frontend
+ops
+└── oncall
+z
+x
+└── y
+`, f.String())
+	for _, n := range f {
+		assert.NoError(n.Validate())
+	}
+
+	f = FromEdges([]Edge{
+		{"backend", "api"},
+		{"eng", "frontend"},
+		{"eng", "docs"},
+		{"eng", "backend"},
+		{"api", "v1"},
+		{"eng", "frontend"},
+		{"ops", "oncall"},
+	}, WithMaxChildren(1))
+	assert.Equal(`eng
+├── frontend
+└── … and 2 more
+ops
+└── oncall
+`, f.String())
+}
+
+func TestFromPathsForest(t *testing.T) {
+	assert := assert.New(t)
+
+	f := FromPathsForest([]string{"usr/bin", "etc/hosts", "usr/lib"}, "/")
+	assert.Equal("usr\n├── bin\n└── lib\netc\n└── hosts\n", f.String())
+	assert.Nil(f[0].Root)
+}
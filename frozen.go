@@ -0,0 +1,161 @@
+package treeprint
+
+// ImmutableTree is a read-only view of a tree. It exposes no mutating
+// methods, so a tree handed out through it can't be modified by the receiver.
+type ImmutableTree interface {
+	// Value returns the value of the Node.
+	Value() Value
+	// Meta returns the meta value of the Node.
+	Meta() MetaValue
+	// Children returns read-only views of the Node children.
+	Children() []ImmutableTree
+	// FindByMeta finds a Node whose meta value matches the provided one by reflect.DeepEqual,
+	// returns nil if not found.
+	FindByMeta(meta MetaValue) ImmutableTree
+	// FindByValue finds a Node whose value matches the provided one by reflect.DeepEqual,
+	// returns nil if not found.
+	FindByValue(value Value) ImmutableTree
+	// Find finds the first Node matched by m, returns nil if not found.
+	Find(m Matcher) ImmutableTree
+	// FindLastNode returns the last Node of a tree, or nil if there are no children.
+	FindLastNode() ImmutableTree
+	// Print renders the tree or subtree as a string. The callbacks of the options
+	// given nodes, as a RenderHook or a StyleRule, are given the nodes of a copy
+	// of the subtree and its ancestors, so that they can't modify the tree.
+	Print(PrinterOptions) string
+	// String renders the tree or subtree as a string with its printer options, as Print does.
+	String() string
+	// Bytes renders the tree or subtree as byteslice, as Print does.
+	Bytes(PrinterOptions) []byte
+	// VisitAll iterates over the tree, branches and nodes in the same order as Tree.VisitAll.
+	VisitAll(fn func(item ImmutableTree))
+	// ChildCount returns the number of children.
+	ChildCount() int
+}
+
+// Freeze returns a read-only view of the tree rooted at n.
+// The view shares the underlying nodes, changes made through the original Tree are visible in it.
+// The view of a nil Node behaves as an empty tree, as the nil Node does.
+func (n *Node) Freeze() ImmutableTree {
+	return frozenNode{n: n}
+}
+
+type frozenNode struct {
+	n *Node
+}
+
+func freeze(t Tree) ImmutableTree {
+	if t == nil {
+		return nil
+	}
+	return frozenNode{n: t.(*Node)}
+}
+
+func (f frozenNode) Value() Value {
+	if f.n == nil {
+		return nil
+	}
+	return f.n.Value
+}
+
+func (f frozenNode) Meta() MetaValue {
+	if f.n == nil {
+		return nil
+	}
+	return f.n.Meta
+}
+
+func (f frozenNode) Children() []ImmutableTree {
+	if f.n == nil {
+		return nil
+	}
+	children := make([]ImmutableTree, 0, len(f.n.Nodes))
+	for _, node := range f.n.Nodes {
+		children = append(children, frozenNode{n: node})
+	}
+	return children
+}
+
+func (f frozenNode) FindByMeta(meta MetaValue) ImmutableTree {
+	return freeze(f.n.FindByMeta(meta))
+}
+
+func (f frozenNode) FindByValue(value Value) ImmutableTree {
+	return freeze(f.n.FindByValue(value))
+}
+
+func (f frozenNode) Find(m Matcher) ImmutableTree {
+	return freeze(f.n.Find(m))
+}
+
+func (f frozenNode) FindLastNode() ImmutableTree {
+	return freeze(f.n.FindLastNode())
+}
+
+func (f frozenNode) Print(p PrinterOptions) string {
+	n, p := f.rendered(p)
+	return n.Print(p)
+}
+
+func (f frozenNode) String() string {
+	return string(f.Bytes(f.n.Options()))
+}
+
+func (f frozenNode) Bytes(p PrinterOptions) []byte {
+	n, p := f.rendered(p)
+	return n.Bytes(p)
+}
+
+// rendered returns the Node to render with the options, and the options to render it with.
+// When the options have callbacks given nodes, the Node is a copy of the subtree whose
+// ancestors are copied without their other children, and the options refer to the copies.
+func (f frozenNode) rendered(p PrinterOptions) (*Node, PrinterOptions) {
+	if f.n == nil || p.renderHook == nil && len(p.styleRules) == 0 && p.less == nil && p.truncationHook == nil {
+		return f.n, p
+	}
+	n := copyTree(f.n)
+	// the copy has the shape of the walk of the tree, the cycles being cut alike
+	copies := map[*Node]*Node{f.n: n}
+	var originals []*Node
+	walkNodes(f.n, func(item *Node, _ int, _ *Node) WalkAction {
+		originals = append(originals, item)
+		return WalkContinue
+	})
+	i := 0
+	walkNodes(n, func(item *Node, _ int, _ *Node) WalkAction {
+		copies[originals[i]] = item
+		i++
+		return WalkContinue
+	})
+	child := n
+	for a := f.n.Root; a != nil; a = a.Root {
+		ancestor := &Node{Meta: a.Meta, Value: a.Value, Nodes: []*Node{child}, status: a.status, description: a.description}
+		child.Root = ancestor
+		copies[a] = ancestor
+		child = ancestor
+	}
+	child.options, n.options = n.options, nil
+
+	if a := p.annotations; a != nil {
+		mapped := &Annotations{nodes: make(map[*Node]Annotation, len(a.nodes)), paths: a.paths}
+		for node, an := range a.nodes {
+			if c, ok := copies[node]; ok {
+				mapped.nodes[c] = an
+			}
+		}
+		p.annotations = mapped
+	}
+	// the copies would only fill the cache with nodes never rendered again
+	p.valueCache = nil
+	return n, p
+}
+
+func (f frozenNode) VisitAll(fn func(item ImmutableTree)) {
+	f.n.VisitAll(func(item *Node) {
+		fn(frozenNode{n: item})
+	})
+}
+
+func (f frozenNode) ChildCount() int {
+	return f.n.ChildCount()
+}
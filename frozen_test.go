@@ -0,0 +1,83 @@
+package treeprint
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeze(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddMetaBranch("m", "one").AddNode("two")
+	tree.AddNode("three")
+
+	frozen := tree.Freeze()
+	assert.Equal(tree.String(), frozen.String())
+	assert.Equal(".", frozen.Value())
+	assert.Equal(2, frozen.ChildCount())
+
+	one := frozen.FindByMeta("m")
+	assert.NotNil(one)
+	assert.Equal("one", one.Value())
+	assert.Len(one.Children(), 1)
+	assert.Equal("three", frozen.FindLastNode().Value())
+	assert.Nil(frozen.FindByValue("missing"))
+	assert.Nil(one.Children()[0].FindLastNode())
+
+	var visited []Value
+	frozen.VisitAll(func(item ImmutableTree) {
+		visited = append(visited, item.Value())
+	})
+	assert.Equal([]Value{"one", "two", "three"}, visited)
+
+	tree.AddNode("four")
+	assert.Equal(3, frozen.ChildCount())
+}
+
+func TestFreezeCallbacks(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddMetaBranch("m", "one").AddNode("two")
+	hash := tree.Hash()
+	expected := tree.Print(NewPrinter())
+	frozen := tree.Freeze()
+	one := tree.FindByValue("one").(*Node)
+
+	// the callbacks are given copies of the nodes, changing them leaves the tree untouched
+	var hooked, matched []*Node
+	pf := NewPrinter(
+		WithRenderHook(func(n *Node, _ int, _ bool, _ io.Writer) bool {
+			hooked = append(hooked, n)
+			n.SetValue("hooked")
+			return false
+		}),
+		WithStyleRules(StyleRule{Match: func(n *Node, _ int) bool {
+			matched = append(matched, n)
+			n.SetMetaValue("changed")
+			return false
+		}}),
+	)
+	frozen.Print(pf)
+	frozen.Bytes(pf)
+	assert.NotEmpty(hooked)
+	assert.NotContains(hooked, one)
+	assert.NotContains(matched, one)
+	assert.Equal(hash, tree.Hash(), "rendering leaves the tree unchanged")
+	assert.Equal(expected, tree.Print(NewPrinter()))
+
+	// the copies keep the annotations set on the nodes, and the ancestors of subtrees
+	annotations := NewAnnotations()
+	annotations.Set(one, Annotation{Marker: "!"})
+	pf = NewPrinter(
+		WithAnnotations(annotations),
+		WithAncestors("/"),
+		WithRenderHook(func(*Node, int, bool, io.Writer) bool { return false }),
+	)
+	sub := frozen.FindByValue("one")
+	assert.Equal(string(one.Bytes(pf)), string(sub.Bytes(pf)))
+	assert.Contains(string(sub.Bytes(pf)), "! [m]  one")
+}
@@ -0,0 +1,156 @@
+package treeprint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FS returns a read-only file system view of the tree, where the values of the nodes
+// are the path elements: the nodes with children are directories and the others are
+// files holding their meta, formatted with %v, or nothing. The root is the "." directory.
+// Nodes whose value is not a valid path element, or is the same as the one of an
+// earlier sibling, are left out.
+func (n *Node) FS() fs.FS {
+	return treeFS{root: n}
+}
+
+type treeFS struct {
+	root *Node
+}
+
+func (t treeFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	node := t.root
+	if name != "." {
+	elems:
+		for _, elem := range strings.Split(name, "/") {
+			for _, child := range fsChildren(node) {
+				if fsName(child) == elem {
+					node = child
+					continue elems
+				}
+			}
+			return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+		}
+	}
+	info := fsInfo{node: node, name: fsName(node)}
+	if node == t.root {
+		info.name = "."
+	}
+	if info.IsDir() {
+		return &fsDir{info: info}, nil
+	}
+	return &fsFile{info: info, Reader: bytes.NewReader(fsContent(node))}, nil
+}
+
+func fsName(n *Node) string {
+	if s, ok := n.Value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(n.Value)
+}
+
+func fsContent(n *Node) []byte {
+	if n.Meta == nil {
+		return nil
+	}
+	return []byte(fmt.Sprint(n.Meta))
+}
+
+// fsChildren returns the children that can be opened, sorted by name.
+func fsChildren(n *Node) []*Node {
+	seen := make(map[string]bool)
+	var nodes []*Node
+	for _, child := range n.children() {
+		name := fsName(child)
+		if seen[name] || name == "." || !fs.ValidPath(name) || strings.Contains(name, "/") {
+			continue
+		}
+		seen[name] = true
+		nodes = append(nodes, child)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return fsName(nodes[i]) < fsName(nodes[j])
+	})
+	return nodes
+}
+
+// fsInfo describes a Node, it implements both fs.FileInfo and fs.DirEntry.
+type fsInfo struct {
+	node *Node
+	name string
+}
+
+func (i fsInfo) Name() string { return i.name }
+
+func (i fsInfo) Size() int64 { return int64(len(fsContent(i.node))) }
+
+func (i fsInfo) Mode() fs.FileMode {
+	if i.IsDir() {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+func (i fsInfo) ModTime() time.Time { return time.Time{} }
+
+func (i fsInfo) IsDir() bool { return len(i.node.children()) > 0 }
+
+func (i fsInfo) Sys() interface{} { return i.node }
+
+func (i fsInfo) Type() fs.FileMode { return i.Mode().Type() }
+
+func (i fsInfo) Info() (fs.FileInfo, error) { return i, nil }
+
+type fsFile struct {
+	info fsInfo
+	*bytes.Reader
+}
+
+func (f *fsFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *fsFile) Close() error { return nil }
+
+type fsDir struct {
+	info    fsInfo
+	entries []fs.DirEntry
+	read    bool
+}
+
+func (d *fsDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+
+func (d *fsDir) Close() error { return nil }
+
+func (d *fsDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: fs.ErrInvalid}
+}
+
+func (d *fsDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	if !d.read {
+		d.read = true
+		for _, child := range fsChildren(d.info.node) {
+			d.entries = append(d.entries, fsInfo{node: child, name: fsName(child)})
+		}
+	}
+	if count <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(d.entries) {
+		count = len(d.entries)
+	}
+	entries := d.entries[:count]
+	d.entries = d.entries[count:]
+	return entries, nil
+}
@@ -0,0 +1,47 @@
+package treeprint
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFS(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	etc := tree.AddBranch("etc")
+	etc.AddMetaNode("127.0.0.1 localhost", "hosts")
+	etc.AddBranch("ssh").AddMetaNode("Port 22", "sshd_config")
+	tree.AddNode("empty")
+	tree.AddNode("etc")
+	tree.AddNode("..")
+
+	fsys := tree.FS()
+	assert.NoError(fstest.TestFS(fsys, "etc/hosts", "etc/ssh/sshd_config", "empty"))
+
+	b, err := fs.ReadFile(fsys, "etc/ssh/sshd_config")
+	assert.NoError(err)
+	assert.Equal("Port 22", string(b))
+
+	entries, err := fs.ReadDir(fsys, ".")
+	assert.NoError(err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.Equal([]string{"empty", "etc"}, names)
+
+	_, err = fsys.Open("etc/missing")
+	assert.ErrorIs(err, fs.ErrNotExist)
+	_, err = fsys.Open("/etc")
+	assert.ErrorIs(err, fs.ErrInvalid)
+
+	w := httptest.NewRecorder()
+	http.FileServer(http.FS(fsys)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/etc/hosts", nil))
+	assert.Equal("127.0.0.1 localhost", w.Body.String())
+}
@@ -2,10 +2,17 @@ module github.com/ououmania/treeprint
 
 go 1.18
 
-require github.com/stretchr/testify v1.7.0
+require (
+	github.com/stretchr/testify v1.7.0
+	golang.org/x/image v0.18.0
+	golang.org/x/text v0.16.0
+	gonum.org/v1/gonum v0.13.0
+	google.golang.org/protobuf v1.34.2
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+	golang.org/x/exp v0.0.0-20230321023759-10a507213a29 // indirect
 )
@@ -0,0 +1,81 @@
+package treeprint
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+)
+
+// HashFunc function type for creating the hash.Hash digests are computed with, see Hash.
+type HashFunc func() hash.Hash
+
+// WithHashFunc computes the digests of Hash with fn instead of SHA-256.
+// It only matters as an option of the tree, given to New or NewWithRoot.
+func WithHashFunc(fn HashFunc) Option {
+	return func(p *PrinterOptions) {
+		p.hashFunc = fn
+	}
+}
+
+// Hash returns a digest of the structure, the values and the meta values of the tree
+// or subtree, so that changes are detected, and identical subtrees found, by comparing digests.
+// The values and meta values are hashed along with their type through their %#v formatting,
+// which is deterministic for maps, but not for pointers, whose addresses are formatted.
+// The digest is SHA-256 unless the tree was created with WithHashFunc.
+func (n *Node) Hash() []byte {
+	if n == nil {
+		return nil
+	}
+	return n.hashes(false)[n]
+}
+
+// Hashes returns the digests of the Node and of all its descendants, as Hash computes
+// them, in a single pass over the tree or subtree. The nodes with equal digests
+// hold identical subtrees.
+func (n *Node) Hashes() map[*Node][]byte {
+	if n == nil {
+		return nil
+	}
+	return n.hashes(true)
+}
+
+// hashes computes the digests bottom-up, every Node hashing the digests of its children.
+// Unless all is set, the digests of the children are dropped once used.
+func (n *Node) hashes(all bool) map[*Node][]byte {
+	newHash := n.Options().hashFunc
+	if newHash == nil {
+		newHash = sha256.New
+	}
+	h := newHash()
+	digests := make(map[*Node][]byte)
+	var scratch [binary.MaxVarintLen64]byte
+	writeLen := func(n int) {
+		h.Write(scratch[:binary.PutUvarint(scratch[:], uint64(n))])
+	}
+	write := func(b []byte) {
+		writeLen(len(b))
+		h.Write(b)
+	}
+	digest := func(node *Node) {
+		h.Reset()
+		write([]byte(fmt.Sprintf("%T %#v", node.Value, node.Value)))
+		if node.Meta != nil {
+			write([]byte(fmt.Sprintf("%T %#v", node.Meta, node.Meta)))
+		} else {
+			write(nil)
+		}
+		children := node.children()
+		writeLen(len(children))
+		for _, child := range children {
+			write(digests[child])
+			if !all {
+				delete(digests, child)
+			}
+		}
+		digests[node] = h.Sum(nil)
+	}
+	n.VisitPostOrder(digest)
+	digest(n)
+	return digests
+}
@@ -0,0 +1,46 @@
+package treeprint
+
+import (
+	"crypto/md5"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash(t *testing.T) {
+	assert := assert.New(t)
+
+	build := func(options ...Option) Tree {
+		tree := New(options...)
+		a := tree.AddMetaBranch("m", "a")
+		a.AddNode(1)
+		a.AddNode(map[string]int{"x": 1, "y": 2})
+		tree.AddBranch("b").AddNode(1)
+		return tree
+	}
+	one, two := build(), build()
+	assert.Len(one.Hash(), 32)
+	assert.Equal(one.Hash(), two.Hash())
+
+	// values, metas and structure all count
+	two.FindByValue("b").SetValue("c")
+	assert.NotEqual(one.Hash(), two.Hash())
+	two.FindByValue("c").SetValue("b")
+	assert.Equal(one.Hash(), two.Hash())
+	two.FindByValue("a").SetMetaValue(nil)
+	assert.NotEqual(one.Hash(), two.Hash())
+	assert.NotEqual(New().AddNode(1).Hash(), New().AddNode("1").Hash())
+	assert.NotEqual(New().AddNode("ab").AddNode("c").Hash(), New().AddNode("a").AddNode("bc").Hash())
+	assert.NotEqual(New().AddBranch("a").AddNode("b").Hash(), New().AddNode("a").AddNode("b").Hash())
+
+	// identical subtrees have equal digests
+	hashes := one.(*Node).Hashes()
+	assert.Len(hashes, 6)
+	a, b := one.FindByValue("a").(*Node), one.FindByValue("b").(*Node)
+	assert.Equal(hashes[a.Nodes[0]], hashes[b.Nodes[0]])
+	assert.Equal(a.Hash(), hashes[a])
+
+	assert.Len(build(WithHashFunc(md5.New)).Hash(), 16)
+	var nilNode *Node
+	assert.Nil(nilNode.Hash())
+}
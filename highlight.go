@@ -0,0 +1,134 @@
+package treeprint
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// Locator is implemented by the matchers able to tell which parts of a text they match,
+// such as the ones returned by Contains and MatchRegexp, see HighlightMatches.
+type Locator interface {
+	// Locate returns the start and end offsets of the non-overlapping matches in text.
+	Locate(text []byte) [][]int
+}
+
+// HighlightMatches renders the nodes matched by m with the style: only the matching parts
+// of their value when m is a Locator, their whole value otherwise. Matchers made with
+// OnMeta highlight the meta value instead. See also WithCollapseUnmatched.
+func HighlightMatches(m Matcher, style Style) Option {
+	return func(p *PrinterOptions) {
+		p.highlight = m
+		p.highlightStyle = style
+	}
+}
+
+// WithCollapseUnmatched renders the children of only the branches having a descendant
+// matched by the Matcher of HighlightMatches, so that the matches stand out of
+// a large tree the way grep output does. The lazy children are produced to be searched.
+func WithCollapseUnmatched() Option {
+	return func(p *PrinterOptions) {
+		p.collapseUnmatched = true
+	}
+}
+
+type containsMatcher string
+
+func (m containsMatcher) MatchValue(value Value) bool {
+	return strings.Contains(sprint(value), string(m))
+}
+
+func (m containsMatcher) MatchMeta(meta MetaValue) bool { return true }
+
+func (m containsMatcher) Locate(text []byte) [][]int {
+	if m == "" {
+		return nil
+	}
+	var parts [][]int
+	for offset := 0; ; {
+		i := bytes.Index(text[offset:], []byte(m))
+		if i < 0 {
+			return parts
+		}
+		parts = append(parts, []int{offset + i, offset + i + len(m)})
+		offset += i + len(m)
+	}
+}
+
+type regexpMatcher struct{ re *regexp.Regexp }
+
+func (m regexpMatcher) MatchValue(value Value) bool   { return m.re.MatchString(sprint(value)) }
+func (m regexpMatcher) MatchMeta(meta MetaValue) bool { return true }
+func (m regexpMatcher) Locate(text []byte) [][]int    { return m.re.FindAllIndex(text, -1) }
+
+// matches reports whether the Node is matched by the Matcher of HighlightMatches.
+func (p *printer) matches(n *Node) bool {
+	m := p.pf.highlight
+	return m != nil && m.MatchValue(n.Value) && m.MatchMeta(n.Meta)
+}
+
+// highlighted returns the formatted meta and value of a matched Node with their matching
+// parts highlighted, and returns them unchanged otherwise. The result is only valid until the next call.
+func (p *printer) highlighted(n *Node, meta, value []byte) ([]byte, []byte) {
+	if !p.matches(n) {
+		return meta, value
+	}
+	metaMatch, valueMatch := Matcher(nil), p.pf.highlight
+	if m, ok := valueMatch.(metaMatcher); ok {
+		metaMatch, valueMatch = m.m, nil
+	}
+	b := p.appendHighlighted(p.marked[:0], meta, metaMatch)
+	i := len(b)
+	b = p.appendHighlighted(b, value, valueMatch)
+	p.marked = b
+	return b[:i:i], b[i:]
+}
+
+func (p *printer) appendHighlighted(dst, text []byte, m Matcher) []byte {
+	if m == nil {
+		return append(dst, text...)
+	}
+	style := p.pf.highlightStyle
+	l, ok := m.(Locator)
+	if !ok {
+		// the separator following the meta value is not part of the match
+		trimmed := bytes.TrimRight(text, " ")
+		dst = style.appendStyled(dst, trimmed)
+		return append(dst, text[len(trimmed):]...)
+	}
+	var end int
+	for _, part := range l.Locate(text) {
+		dst = append(dst, text[end:part[0]]...)
+		dst = style.appendStyled(dst, text[part[0]:part[1]])
+		end = part[1]
+	}
+	return append(dst, text[end:]...)
+}
+
+// expanded reports whether the children of the Node are rendered, see WithCollapseUnmatched.
+func (p *printer) expanded(n *Node) bool {
+	if !p.pf.collapseUnmatched || p.pf.highlight == nil {
+		return true
+	}
+	if p.expand == nil {
+		p.expand = make(map[*Node]bool)
+	}
+	if expand, ok := p.expand[n]; ok {
+		return expand
+	}
+	// the whole subtree is resolved at once, each branch after its children
+	hasMatch := func(branch *Node) bool {
+		for _, node := range branch.children() {
+			if p.expand[node] || p.matches(node) {
+				return true
+			}
+		}
+		return false
+	}
+	postOrderWalk(n, func(item *Node, _ int) WalkAction {
+		p.expand[item] = hasMatch(item)
+		return WalkContinue
+	})
+	p.expand[n] = hasMatch(n)
+	return p.expand[n]
+}
@@ -0,0 +1,48 @@
+package treeprint
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	src := tree.AddBranch("src")
+	src.AddNode("main.go")
+	src.AddMetaNode("go", "main_test.go")
+	docs := tree.AddBranch("docs")
+	docs.AddNode("readme.md")
+	tree.AddMetaNode(42, "go.mod")
+
+	f := NewPrinter(HighlightMatches(Contains("go"), Reverse))
+	assert.Equal(`.
+├── src
+│   ├── main.`+"\x1b[7mgo\x1b[0m"+`
+│   └── [go]  main_test.`+"\x1b[7mgo\x1b[0m"+`
+├── docs
+│   └── readme.md
+└── [42]  `+"\x1b[7mgo\x1b[0m"+`.mod
+`, string(tree.Bytes(f)))
+
+	f = NewPrinter(HighlightMatches(MatchRegexp(regexp.MustCompile(`^\w+\.md$`)), Bold), WithCollapseUnmatched())
+	assert.Equal(`.
+├── src
+├── docs
+│   └── `+"\x1b[1mreadme.md\x1b[0m"+`
+└── [42]  go.mod
+`, string(tree.Bytes(f)))
+
+	// the matchers unable to locate the matches highlight the whole value, or meta value
+	f = NewPrinter(HighlightMatches(OnMeta(TypeOf[int]()), Red))
+	assert.Contains(string(tree.Bytes(f)), "└── \x1b[31m[42]\x1b[0m  go.mod\n")
+
+	// multiline matches are styled line by line
+	multi := New()
+	multi.AddNode("ab\ncd")
+	f = NewPrinter(HighlightMatches(MatchRegexp(regexp.MustCompile(`b\nc`)), Bold))
+	assert.Equal(".\n└── a\x1b[1mb\x1b[0m\n    \x1b[1mc\x1b[0md\n", string(multi.Bytes(f)))
+}
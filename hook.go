@@ -0,0 +1,37 @@
+package treeprint
+
+import (
+	"bytes"
+	"io"
+)
+
+// RenderHook function type for taking over the printing of a Node: it writes into w what
+// follows the connector on the line of the Node, or returns false to have the Node
+// printed as usual, in which case what it wrote is discarded. The depth is 0 for the Node
+// the rendering starts from, 1 for its children and so on, and isLast tells whether the Node
+// is the last one of its siblings. A trailing line break is dropped, and the other ones
+// start lines padded as for multiline values. The hook is given the Node itself, it must
+// not modify it, nor its tree, which may be shared through an ImmutableTree.
+type RenderHook func(n *Node, depth int, isLast bool, w io.Writer) bool
+
+// WithRenderHook has every Node offered to h before being printed, see RenderHook.
+// The output of the hook is not cached by a ValueCache nor by an IncrementalPrinter
+// beyond the Node revision, so it should only depend on the Node.
+func WithRenderHook(h RenderHook) Option {
+	return func(p *PrinterOptions) {
+		p.renderHook = h
+	}
+}
+
+// hook offers the Node to the RenderHook, returning its output if it took over.
+// The output is only valid until the next call.
+func (p *printer) hook(n *Node, depth int, last bool) ([]byte, bool) {
+	if p.pf.renderHook == nil {
+		return nil, false
+	}
+	p.hooked.Reset()
+	if !p.pf.renderHook(n, depth, last, &p.hooked) {
+		return nil, false
+	}
+	return bytes.TrimSuffix(p.hooked.Bytes(), []byte("\n")), true
+}
@@ -0,0 +1,43 @@
+package treeprint
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderHook(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	svc := tree.AddMetaBranch("up", "services")
+	svc.AddMetaNode("up", "api")
+	svc.AddMetaNode("down", "db")
+	tree.AddNode("notes")
+
+	var calls []string
+	hook := func(n *Node, depth int, isLast bool, w io.Writer) bool {
+		calls = append(calls, fmt.Sprintf("%v:%d:%t", n.Value, depth, isLast))
+		status, ok := n.Meta.(string)
+		if !ok {
+			io.WriteString(w, "discarded")
+			return false
+		}
+		if status == "down" {
+			fmt.Fprintf(w, "%-8v DOWN\nsince 5m\n", n.Value)
+			return true
+		}
+		fmt.Fprintf(w, "%-8v ok", n.Value)
+		return true
+	}
+	assert.Equal(`.
+├── services ok
+│   ├── api      ok
+│   └── db       DOWN
+│       since 5m
+└── notes
+`, string(tree.Bytes(NewPrinter(WithRenderHook(hook)))))
+	assert.Equal([]string{".:0:true", "services:1:false", "api:2:false", "db:2:true", "notes:1:true"}, calls)
+}
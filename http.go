@@ -0,0 +1,91 @@
+package treeprint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Handler returns an http.Handler serving the tree, rendered on every request.
+// The format is picked from the Accept header: JSON for application/json (see MarshalJSON),
+// an HTML page for text/html, and plain text otherwise. The "format" query parameter
+// overrides it with "json", "html" or "text". The "depth" query parameter limits
+// the depth of the tree, and the "filter" one keeps only the nodes whose value contains it,
+// along with their ancestors and subtrees. The filter searches the whole tree,
+// producing its lazy children, only the depth keeps them from being produced.
+// The requests are rendered one at a time, the responses are written out afterwards.
+// The tree must not be modified while being served.
+func Handler(t Tree) http.Handler {
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := t.(*Node)
+		query := r.URL.Query()
+		depth := 0
+		if s := query.Get("depth"); s != "" {
+			var err error
+			depth, err = strconv.Atoi(s)
+			if err != nil || depth < 1 {
+				http.Error(w, "treeprint: invalid depth "+strconv.Quote(s), http.StatusBadRequest)
+				return
+			}
+		}
+		var match func(*Node) bool
+		if filter := query.Get("filter"); filter != "" {
+			match = func(item *Node) bool {
+				return strings.Contains(valueString(item), filter)
+			}
+		}
+
+		format := query.Get("format")
+		if format == "" {
+			accept := r.Header.Get("Accept")
+			switch {
+			case strings.Contains(accept, "application/json"):
+				format = "json"
+			case strings.Contains(accept, "text/html"):
+				format = "html"
+			}
+		}
+		contentType, body, err := render(r.Context(), &mu, n, depth, match, format)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Write(body)
+	})
+}
+
+// render renders the tree served by Handler holding mu, copying the nodes kept by
+// the depth and the match, if any. The nodes deeper than requested are not copied,
+// nor produced if lazy and nothing is matched.
+func render(ctx context.Context, mu *sync.Mutex, n *Node, depth int, match func(*Node) bool, format string) (string, []byte, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if depth > 0 || match != nil {
+		n = copyMatching(n, depth, match)
+	}
+	switch format {
+	case "json":
+		b, err := json.Marshal(n)
+		return "application/json", b, err
+	case "html":
+		var b bytes.Buffer
+		b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>")
+		b.WriteString(html.EscapeString(valueString(n)))
+		b.WriteString("</title></head><body><pre>")
+		b.WriteString(html.EscapeString(n.String()))
+		b.WriteString("</pre></body></html>\n")
+		return "text/html; charset=utf-8", b.Bytes(), nil
+	}
+	var b bytes.Buffer
+	if err := n.Render(ctx, &b); err != nil {
+		return "", nil, err
+	}
+	return "text/plain; charset=utf-8", b.Bytes(), nil
+}
@@ -0,0 +1,100 @@
+package treeprint
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	one := tree.AddBranch("one")
+	one.AddNode("a")
+	one.AddBranch("b").AddNode("c")
+	tree.AddNode("<two>")
+	h := Handler(tree)
+
+	serve := func(target, accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("/", "")
+	assert.Equal("text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(tree.String(), w.Body.String())
+
+	w = serve("/?depth=1", "application/json")
+	assert.Equal("application/json", w.Header().Get("Content-Type"))
+	assert.Equal(`{"value":".","children":[{"value":"one"},{"value":"\u003ctwo\u003e"}]}`, w.Body.String())
+
+	w = serve("/?filter=c", "text/html,application/xhtml+xml")
+	assert.Equal("text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(w.Body.String(), "<pre>.\n└── one\n    └── b\n        └── c\n</pre>")
+
+	w = serve("/?filter=c&depth=2", "")
+	assert.Equal(".\n└── one\n    └── b\n", w.Body.String(), "the ancestors of deeper matches are kept")
+
+	w = serve("/?format=html", "")
+	assert.Contains(w.Body.String(), "└── &lt;two&gt;")
+
+	w = serve("/?depth=x", "")
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Len(tree.(*Node).Nodes[0].Nodes[1].Nodes, 1, "the tree is left unchanged")
+}
+
+func TestHandlerDepthLazy(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	tree := lazyDir("root", &calls)
+	pf := NewPrinter(WithEdgeStyle(ASCIIEdgeStyle))
+	tree.options = &pf
+	r := httptest.NewRequest(http.MethodGet, "/?depth=2", nil)
+	w := httptest.NewRecorder()
+	Handler(tree).ServeHTTP(w, r)
+	assert.Equal(`root
+|-- root/a
+|   |-- root/a/a
+|   `+"`"+`-- root/a/b
+`+"`"+`-- root/b
+    |-- root/b/a
+    `+"`"+`-- root/b/b
+`, w.Body.String(), "the copy keeps the options of the tree")
+	assert.Equal(3, calls, "the children deeper than requested are not produced")
+}
+
+func TestHandlerConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	tree := lazyDir("root", &calls)
+	pf := NewPrinter(WithValueCache(NewValueCache()))
+	tree.options = &pf
+	h := Handler(tree)
+	var wg sync.WaitGroup
+	bodies := make([]string, 8)
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?depth=3", nil))
+			bodies[i] = w.Body.String()
+		}(i)
+	}
+	wg.Wait()
+	for _, body := range bodies {
+		assert.Equal(bodies[0], body)
+	}
+	assert.Contains(bodies[0], "root/a/b/b")
+}
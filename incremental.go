@@ -0,0 +1,191 @@
+package treeprint
+
+import (
+	"bytes"
+	"io"
+)
+
+// IncrementalPrinter renders the same tree over and over, caching the rendered lines
+// of every subtree and re-rendering only the subtrees changed since the previous render.
+// Changes are detected through MarkDirty, which all the mutating methods call.
+// It is meant for TUIs redrawing a live-updating tree many times a second.
+// An IncrementalPrinter is not safe for concurrent use.
+type IncrementalPrinter struct {
+	pf   PrinterOptions
+	top  map[*Node]*cachedSubtree
+	line *printer
+	buf  bytes.Buffer
+}
+
+// cachedSubtree holds the lines of a subtree relative to the level of its Node,
+// they depend on whether the Node is the last one of its siblings and, when
+// the options make the lines depend on the depth, on its level. The cache is shaped like the tree itself,
+// so the subtrees removed from the tree are dropped along with their parent entry.
+type cachedSubtree struct {
+	rev      uint64
+	level    int
+	last     bool
+	body     []byte
+	children map[*Node]*cachedSubtree
+}
+
+// NewIncrementalPrinter creates an IncrementalPrinter using the given printer options.
+func NewIncrementalPrinter(options ...Option) *IncrementalPrinter {
+	r := &IncrementalPrinter{
+		pf: NewPrinter(options...),
+	}
+	// the cached bodies are encoded when written into the output
+	r.line = newPrinter(&r.buf, r.pf.raw())
+	return r
+}
+
+// Render writes the tree rooted at n into w, n is rendered as the root of the output.
+// Its line, the fence and the ancestors are written as PrintTo writes them, uncached.
+// It returns the number of bytes written and the first write error encountered.
+func (r *IncrementalPrinter) Render(w io.Writer, n *Node) (int64, error) {
+	if n == nil {
+		return 0, nil
+	}
+	p := newPrinter(w, r.pf)
+	defer p.release()
+	p.openFence()
+	defer p.closeFence()
+	p.writeAncestors(n)
+	n.renderHeader(p)
+	children := r.pf.children(n)
+	top := make(map[*Node]*cachedSubtree, len(children))
+	path := map[*Node]bool{n: true}
+	for i, node := range children {
+		c := r.subtree(node, 0, i == len(children)-1, r.top[node], path)
+		top[node] = c
+		p.Write(c.body)
+	}
+	r.top = top
+	return p.n, p.err
+}
+
+// Reset drops all the cached subtrees.
+func (r *IncrementalPrinter) Reset() {
+	r.top = nil
+}
+
+func (r *IncrementalPrinter) valid(c *cachedSubtree, n *Node, level int, last bool) bool {
+	return c != nil && c.rev == n.rev && c.last == last &&
+		(!r.pf.levelDependent() || c.level == level)
+}
+
+// subtree returns the cache entry of the subtree at n, reusing the previous entry
+// and the entries of the unchanged descendants where possible. The nodes on path,
+// reached again from one of their own descendants, are rendered as cycles.
+func (r *IncrementalPrinter) subtree(n *Node, level int, last bool, prev *cachedSubtree, path map[*Node]bool) *cachedSubtree {
+	if r.valid(prev, n, level, last) {
+		return prev
+	}
+	if path[n] {
+		return r.cycle(n, level, last)
+	}
+	type frame struct {
+		node  *Node
+		i     int
+		prev  *cachedSubtree
+		entry *cachedSubtree
+	}
+	newFrame := func(node *Node, level int, last bool, prev *cachedSubtree) frame {
+		node.cached = true
+		path[node] = true
+		return frame{
+			node: node,
+			prev: prev,
+			entry: &cachedSubtree{
+				rev:      node.rev,
+				level:    level,
+				last:     last,
+				body:     r.render(node, level, last),
+				children: make(map[*Node]*cachedSubtree),
+			},
+		}
+	}
+	stack := []frame{newFrame(n, level, last, prev)}
+	var entry *cachedSubtree
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		var children []*Node
+		if r.pf.maxDepth <= 0 || top.entry.level+2 <= r.pf.maxDepth {
+			children = r.pf.children(top.node)
+		}
+		if top.i == len(children) {
+			entry = top.entry
+			delete(path, top.node)
+			stack = stack[:len(stack)-1]
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1].entry
+				parent.children[top.node] = entry
+				parent.body = r.indent(parent.body, entry.body, parent.last)
+			}
+			continue
+		}
+		node := children[top.i]
+		top.i++
+		nodeLast := top.i == len(children)
+		var old *cachedSubtree
+		if top.prev != nil {
+			old = top.prev.children[node]
+		}
+		if r.valid(old, node, top.entry.level+1, nodeLast) {
+			top.entry.children[node] = old
+			top.entry.body = r.indent(top.entry.body, old.body, top.entry.last)
+			continue
+		}
+		if path[node] {
+			c := r.cycle(node, top.entry.level+1, nodeLast)
+			top.entry.children[node] = c
+			top.entry.body = r.indent(top.entry.body, c.body, top.entry.last)
+			continue
+		}
+		stack = append(stack, newFrame(node, top.entry.level+1, nodeLast, old))
+	}
+	return entry
+}
+
+// cycle returns the entry of a Node reached again from one of its own descendants,
+// rendered as printNodes does, without its children.
+func (r *IncrementalPrinter) cycle(n *Node, level int, last bool) *cachedSubtree {
+	r.buf.Reset()
+	r.line.levelOffset = level
+	r.line.setEnded(0, last)
+	printCycle(r.line, 0, last, n)
+	return &cachedSubtree{
+		rev:   n.rev,
+		level: level,
+		last:  last,
+		body:  append([]byte(nil), r.buf.Bytes()...),
+	}
+}
+
+// render returns the line of a single Node relative to its level.
+func (r *IncrementalPrinter) render(n *Node, level int, last bool) []byte {
+	r.buf.Reset()
+	r.line.levelOffset = level
+	r.line.setEnded(0, last)
+	printValues(r.line, 0, last, n)
+	return append([]byte(nil), r.buf.Bytes()...)
+}
+
+// indent appends every line of body to dst, prefixed with the segment of a level
+// whose Node is either the last one of its siblings or not.
+func (r *IncrementalPrinter) indent(dst, body []byte, last bool) []byte {
+	seg := r.line.link
+	if last {
+		seg = r.line.blank
+	}
+	for len(body) > 0 {
+		i := bytes.IndexByte(body, '\n') + 1
+		if i == 0 {
+			i = len(body)
+		}
+		dst = append(dst, seg...)
+		dst = append(dst, body[:i]...)
+		body = body[i:]
+	}
+	return dst
+}
@@ -0,0 +1,93 @@
+package treeprint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncrementalPrinter(t *testing.T) {
+	assert := assert.New(t)
+
+	var printed []Value
+	r := NewIncrementalPrinter(WithValuePrint(func(v Value, w io.Writer) {
+		printed = append(printed, v)
+		fmt.Fprint(w, v)
+	}))
+	render := func(tree Tree) string {
+		printed = nil
+		buf := new(bytes.Buffer)
+		_, err := r.Render(buf, tree.(*Node))
+		assert.NoError(err)
+		return buf.String()
+	}
+
+	tree := New()
+	one := tree.AddBranch("one")
+	one.AddNode("a").AddNode("b\nc")
+	two := tree.AddBranch("two")
+	two.AddBranch("d").AddNode("e")
+	tree.AddNode("three")
+
+	assert.Equal(tree.String(), render(tree))
+	assert.Len(printed, 8)
+
+	assert.Equal(tree.String(), render(tree))
+	assert.Equal([]Value{"."}, printed)
+
+	two.FindLastNode().SetValue("D")
+	assert.Equal(tree.String(), render(tree))
+	assert.Equal([]Value{".", "two", "D"}, printed)
+
+	// the last sibling changes, so the previous one gets a different edge
+	tree.(*Node).Nodes = tree.(*Node).Nodes[:2]
+	tree.MarkDirty()
+	assert.Equal(tree.String(), render(tree))
+	assert.Equal([]Value{".", "two"}, printed)
+
+	one.AddNode("f")
+	assert.Equal(tree.String(), render(tree))
+	// "b\nc" is not the last one anymore
+	assert.Equal([]Value{".", "one", "b\nc", "f"}, printed)
+}
+
+func TestIncrementalPrinterOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	one := tree.AddMetaBranch("m", "one")
+	one.AddNode("a<b")
+	tree.AddNode("two")
+	annotations := NewAnnotations()
+	annotations.SetPath(Annotation{Marker: "+ "})
+	annotations.SetPath(Annotation{Suffix: " !"}, "one")
+
+	for _, options := range [][]Option{
+		{HighlightMatches(Equal("."), Bold)},
+		{WithAnnotations(annotations)},
+		{WithStyleRules(StyleRule{Match: AtDepth(0), Style: Bold})},
+		{WithRenderHook(func(n *Node, depth int, _ bool, w io.Writer) bool {
+			fmt.Fprintf(w, "%d:%v", depth, n.Value)
+			return true
+		})},
+		{WithCodeFence("text", "caption")},
+		{WithHTMLPre("text", "caption")},
+	} {
+		var buf bytes.Buffer
+		_, err := NewIncrementalPrinter(options...).Render(&buf, tree.(*Node))
+		assert.NoError(err)
+		assert.Equal(string(tree.Bytes(NewPrinter(options...))), buf.String())
+	}
+
+	var buf bytes.Buffer
+	_, err := NewIncrementalPrinter(WithAncestors("/")).Render(&buf, one.(*Node))
+	assert.NoError(err)
+	assert.Equal(string(one.Bytes(NewPrinter(WithAncestors("/")))), buf.String())
+
+	n, err := NewIncrementalPrinter().Render(&buf, nil)
+	assert.NoError(err)
+	assert.Zero(n)
+}
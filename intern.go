@@ -0,0 +1,65 @@
+package treeprint
+
+// Interner shares one copy of every distinct string value or meta value between the nodes,
+// which cuts the memory of huge trees where the same strings, as file extensions
+// or package names, are repeated thousands of times. The values are interned together
+// with their interface boxing, so the nodes holding equal strings share both.
+// Values of other types are left as they are. An Interner is not safe for concurrent use.
+type Interner struct {
+	values map[string]Value
+}
+
+// NewInterner creates an empty Interner.
+func NewInterner() *Interner {
+	return &Interner{values: make(map[string]Value)}
+}
+
+// Intern returns the shared copy of v if it is a string, v itself otherwise.
+// The first occurrence of a string is copied, so that it doesn't keep
+// alive a larger string it was sliced from. A nil Interner returns v unchanged.
+func (in *Interner) Intern(v Value) Value {
+	s, ok := v.(string)
+	if !ok || in == nil {
+		return v
+	}
+	if shared, ok := in.values[s]; ok {
+		return shared
+	}
+	if in.values == nil {
+		in.values = make(map[string]Value)
+	}
+	s = string(append([]byte(nil), s...))
+	v = s
+	in.values[s] = v
+	return v
+}
+
+// Len returns the number of distinct strings interned.
+func (in *Interner) Len() int {
+	if in == nil {
+		return 0
+	}
+	return len(in.values)
+}
+
+// Intern replaces the string values and metas of the Node and its descendants
+// with the shared copies of the Interner, for trees already built.
+// It doesn't mark the nodes dirty as the rendering is unchanged.
+// Nothing is done for a nil Interner.
+func (n *Node) Intern(in *Interner) {
+	if n == nil || in == nil {
+		return
+	}
+	n.Value = in.Intern(n.Value)
+	n.Meta = in.Intern(n.Meta)
+	n.VisitAll(func(item *Node) {
+		item.Value = in.Intern(item.Value)
+		item.Meta = in.Intern(item.Meta)
+	})
+}
+
+// SetInterner makes the arena intern the values and metas of the nodes it allocates
+// for AddNode, AddBranch and their meta variants. A nil Interner stops interning.
+func (a *NodeArena) SetInterner(in *Interner) {
+	a.interner = in
+}
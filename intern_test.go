@@ -0,0 +1,57 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterner(t *testing.T) {
+	assert := assert.New(t)
+
+	in := NewInterner()
+	arena := NewNodeArena(0)
+	arena.SetInterner(in)
+	tree := NewWithArena(".", arena)
+	for _, dir := range []string{"a", "b", "c"} {
+		branch := tree.AddBranch(dir)
+		branch.AddMetaNode("go", "main.go")
+		branch.AddMetaNode("go", "util.go")
+		branch.AddNode(42)
+	}
+	assert.Equal(6, in.Len())
+	assert.Equal("main.go", tree.FindByPath("b", "main.go").(*Node).Value)
+
+	shared := in.Intern("main.go")
+	assert.Zero(testing.AllocsPerRun(10, func() {
+		shared = in.Intern("main.go")
+	}))
+	assert.Equal(Value("main.go"), shared)
+	assert.Equal(6, in.Len())
+
+	plain := New()
+	plain.AddMetaNode("txt", "notes").AddNode(1.5)
+	plain.Intern(in)
+	assert.Equal(9, in.Len(), "the root, the value and the meta are interned")
+	assert.Equal(".\n├── [txt]  notes\n└── 1.5\n", plain.String())
+}
+
+func TestInternerNil(t *testing.T) {
+	assert := assert.New(t)
+
+	var in *Interner
+	assert.NotPanics(func() {
+		assert.Equal(Value("a"), in.Intern("a"))
+		assert.Equal(0, in.Len())
+		tree := New()
+		tree.AddNode("a")
+		tree.Intern(nil)
+		assert.Equal(".\n└── a\n", tree.String())
+	})
+
+	var zero Interner
+	assert.NotPanics(func() {
+		zero.Intern("a")
+	})
+	assert.Equal(1, zero.Len())
+}
@@ -0,0 +1,60 @@
+// Package lcs computes the line diffs of treeprint and treetest from the longest
+// common subsequence of the two sequences compared.
+package lcs
+
+// Op is a step of an edit script: kept (' '), removed from a ('-') or added from b ('+').
+// A and B are the positions reached in a and b.
+type Op struct {
+	Kind byte
+	A, B int
+}
+
+// Diff returns the edit script turning a sequence a of n elements into a sequence b
+// of m elements, equal reporting whether a[i] and b[j] are equal. The common prefix
+// and suffix are kept as they are, the table is only built for the elements between them.
+func Diff(n, m int, equal func(i, j int) bool) []Op {
+	ops := make([]Op, 0, n+m)
+	start := 0
+	for start < n && start < m && equal(start, start) {
+		ops = append(ops, Op{Kind: ' ', A: start, B: start})
+		start++
+	}
+	endA, endB := n, m
+	for endA > start && endB > start && equal(endA-1, endB-1) {
+		endA--
+		endB--
+	}
+	// lcs[i*cols+j] is the length of the longest common subsequence of a[start+i:endA] and b[start+j:endB]
+	rows, cols := endA-start+1, endB-start+1
+	lcs := make([]int, rows*cols)
+	for i := rows - 2; i >= 0; i-- {
+		for j := cols - 2; j >= 0; j-- {
+			switch {
+			case equal(start+i, start+j):
+				lcs[i*cols+j] = lcs[(i+1)*cols+j+1] + 1
+			case lcs[(i+1)*cols+j] >= lcs[i*cols+j+1]:
+				lcs[i*cols+j] = lcs[(i+1)*cols+j]
+			default:
+				lcs[i*cols+j] = lcs[i*cols+j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < rows-1 || j < cols-1 {
+		switch {
+		case i < rows-1 && j < cols-1 && equal(start+i, start+j):
+			ops = append(ops, Op{Kind: ' ', A: start + i, B: start + j})
+			i, j = i+1, j+1
+		case j == cols-1 || i < rows-1 && lcs[(i+1)*cols+j] >= lcs[i*cols+j+1]:
+			ops = append(ops, Op{Kind: '-', A: start + i, B: start + j})
+			i++
+		default:
+			ops = append(ops, Op{Kind: '+', A: start + i, B: start + j})
+			j++
+		}
+	}
+	for k := 0; endA+k < n; k++ {
+		ops = append(ops, Op{Kind: ' ', A: endA + k, B: endB + k})
+	}
+	return ops
+}
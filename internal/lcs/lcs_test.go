@@ -0,0 +1,41 @@
+package lcs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	diff := func(a, b string) string {
+		var out strings.Builder
+		for _, op := range Diff(len(a), len(b), func(i, j int) bool { return a[i] == b[j] }) {
+			out.WriteByte(op.Kind)
+			if op.Kind == '+' {
+				out.WriteByte(b[op.B])
+			} else {
+				out.WriteByte(a[op.A])
+			}
+		}
+		return out.String()
+	}
+	assert.Equal(" a b-c+x d", diff("abcd", "abxd"))
+	assert.Equal("-a-b", diff("ab", ""))
+	assert.Equal("+a+b", diff("", "ab"))
+	assert.Equal(" a-b c-d e", diff("abcde", "ace"))
+	assert.Empty(diff("", ""))
+
+	// the common prefix and suffix are compared once, not for every cell of a table
+	a := strings.Repeat("x", 1000)
+	calls := 0
+	ops := Diff(len(a)+1, len(a), func(i, j int) bool {
+		calls++
+		return true
+	})
+	assert.Len(ops, len(a)+1)
+	assert.Equal(byte('-'), ops[len(a)].Kind)
+	assert.Less(calls, 2*len(a)+2)
+}
@@ -0,0 +1,45 @@
+//go:build go1.23
+
+package treeprint
+
+import "iter"
+
+// All returns an iterator over the descendants of n in the same order as VisitAll.
+func (n *Node) All() iter.Seq[*Node] {
+	return n.PreOrder()
+}
+
+// PreOrder returns an iterator over the descendants of n in the same order as VisitPreOrder.
+func (n *Node) PreOrder() iter.Seq[*Node] {
+	return func(yield func(*Node) bool) {
+		n.Walk(func(item *Node) WalkAction {
+			if !yield(item) {
+				return WalkStop
+			}
+			return WalkContinue
+		})
+	}
+}
+
+// PostOrder returns an iterator over the descendants of n in the same order as VisitPostOrder.
+func (n *Node) PostOrder() iter.Seq[*Node] {
+	return func(yield func(*Node) bool) {
+		postOrderWalk(n, func(item *Node, _ int) WalkAction {
+			if !yield(item) {
+				return WalkStop
+			}
+			return WalkContinue
+		})
+	}
+}
+
+// Leaves returns an iterator over the descendants of n that have no children, in pre-order.
+func (n *Node) Leaves() iter.Seq[*Node] {
+	return func(yield func(*Node) bool) {
+		for node := range n.PreOrder() {
+			if len(node.children()) == 0 && !yield(node) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,36 @@
+//go:build go1.23
+
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collect(seq func(func(*Node) bool)) []Value {
+	var values []Value
+	for n := range seq {
+		values = append(values, n.Value)
+	}
+	return values
+}
+
+func TestIterators(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := traverseTree().(*Node)
+	assert.Equal([]Value{"one", "a", "b", "two", "c", "three"}, collect(tree.All()))
+	assert.Equal([]Value{"one", "a", "b", "two", "c", "three"}, collect(tree.PreOrder()))
+	assert.Equal([]Value{"a", "b", "c", "two", "one", "three"}, collect(tree.PostOrder()))
+	assert.Equal([]Value{"a", "b", "c", "three"}, collect(tree.Leaves()))
+
+	var visited []Value
+	for n := range tree.PostOrder() {
+		visited = append(visited, n.Value)
+		if n.Value == "c" {
+			break
+		}
+	}
+	assert.Equal([]Value{"a", "b", "c"}, visited)
+}
@@ -0,0 +1,189 @@
+package treeprint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// MarshalJSON encodes the tree or subtree as nested objects holding the "value",
+// the "meta" and the "children" of every Node, the last two being omitted when empty.
+// The Root pointers are not followed, so that a Node can be encoded as part of other values.
+func (n *Node) MarshalJSON() ([]byte, error) {
+	if n == nil {
+		return []byte("null"), nil
+	}
+	var buf bytes.Buffer
+	type frame struct {
+		parent *Node
+		nodes  []*Node
+		i      int
+	}
+	if err := writeJSONNode(&buf, n); err != nil {
+		return nil, err
+	}
+	// a Node reached again from one of its own descendants is encoded without its children
+	path := map[*Node]bool{n: true}
+	stack := []frame{{parent: n, nodes: n.children()}}
+	if len(stack[0].nodes) == 0 {
+		buf.WriteString("}")
+		return buf.Bytes(), nil
+	}
+	buf.WriteString(`,"children":[`)
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.nodes) {
+			buf.WriteString("]}")
+			delete(path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.nodes[top.i]
+		if top.i > 0 {
+			buf.WriteString(",")
+		}
+		top.i++
+		if err := writeJSONNode(&buf, node); err != nil {
+			return nil, err
+		}
+		if nodes := node.children(); !path[node] && len(nodes) > 0 {
+			buf.WriteString(`,"children":[`)
+			path[node] = true
+			stack = append(stack, frame{parent: node, nodes: nodes})
+			continue
+		}
+		buf.WriteString("}")
+	}
+	return buf.Bytes(), nil
+}
+
+// writeJSONNode writes the beginning of the object of a Node, up to its children.
+func writeJSONNode(buf *bytes.Buffer, n *Node) error {
+	value, err := json.Marshal(n.Value)
+	if err != nil {
+		return fmt.Errorf("treeprint: value %v: %w", n.Value, err)
+	}
+	buf.WriteString(`{"value":`)
+	buf.Write(value)
+	if n.Meta != nil {
+		meta, err := json.Marshal(n.Meta)
+		if err != nil {
+			return fmt.Errorf("treeprint: meta %v: %w", n.Meta, err)
+		}
+		buf.WriteString(`,"meta":`)
+		buf.Write(meta)
+	}
+	return nil
+}
+
+// jsonNode is the form of a Node encoded by MarshalJSON.
+type jsonNode struct {
+	Value    interface{} `json:"value"`
+	Meta     interface{} `json:"meta"`
+	Children []jsonNode  `json:"children"`
+}
+
+// UnmarshalJSON replaces the Node content with the tree decoded from the form
+// encoded by MarshalJSON. Numbers are decoded as json.Number.
+func (n *Node) UnmarshalJSON(b []byte) error {
+	var decoded jsonNode
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&decoded); err != nil {
+		return fmt.Errorf("treeprint: %w", err)
+	}
+	n.Meta, n.Value, n.Nodes = decoded.Meta, decoded.Value, nil
+	n.lazy = nil
+	type entry struct {
+		decoded *jsonNode
+		node    *Node
+	}
+	stack := []entry{{decoded: &decoded, node: n}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for i := range e.decoded.Children {
+			c := &e.decoded.Children[i]
+			child := e.node.newChild(c.Meta, c.Value)
+			e.node.Nodes = append(e.node.Nodes, child)
+			stack = append(stack, entry{decoded: c, node: child})
+		}
+	}
+	n.MarkDirty()
+	return nil
+}
+
+// jsonParseNode is the form of a Node read by ParseJSON, keeping track of the missing values.
+type jsonParseNode struct {
+	Value    json.RawMessage `json:"value"`
+	Meta     json.RawMessage `json:"meta"`
+	Children []jsonParseNode `json:"children"`
+}
+
+// ParseJSON reads a tree in the form encoded by MarshalJSON, handling malformed
+// nodes according to mode. Numbers are decoded as json.Number.
+// With StrictParse the objects must not hold other fields and every Node needs a value,
+// with LenientParse the other fields are ignored and the nodes missing a value
+// are given a Suspect meta.
+func ParseJSON(r io.Reader, mode ParseMode) (Tree, error) {
+	var decoded jsonParseNode
+	d := json.NewDecoder(r)
+	if mode == StrictParse {
+		d.DisallowUnknownFields()
+	}
+	if err := d.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("treeprint: %w", err)
+	}
+	type entry struct {
+		decoded *jsonParseNode
+		node    *Node
+		path    string
+	}
+	root := &Node{}
+	stack := []entry{{decoded: &decoded, node: root, path: "$"}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if err := decodeJSONParseNode(e.decoded, e.node, e.path, mode); err != nil {
+			return nil, err
+		}
+		for i := range e.decoded.Children {
+			child := e.node.newChild(nil, nil)
+			e.node.Nodes = append(e.node.Nodes, child)
+			stack = append(stack, entry{decoded: &e.decoded.Children[i], node: child,
+				path: fmt.Sprintf("%s.children[%d]", e.path, i)})
+		}
+	}
+	return root, nil
+}
+
+// decodeJSONParseNode sets the value and meta of n, path locates the Node in the document.
+func decodeJSONParseNode(decoded *jsonParseNode, n *Node, path string, mode ParseMode) error {
+	decode := func(raw json.RawMessage) (interface{}, error) {
+		var v interface{}
+		if len(raw) == 0 {
+			return nil, nil
+		}
+		d := json.NewDecoder(bytes.NewReader(raw))
+		d.UseNumber()
+		if err := d.Decode(&v); err != nil {
+			return nil, fmt.Errorf("treeprint: %s: %w", path, err)
+		}
+		return v, nil
+	}
+	var err error
+	if n.Value, err = decode(decoded.Value); err != nil {
+		return err
+	}
+	if n.Meta, err = decode(decoded.Meta); err != nil {
+		return err
+	}
+	if decoded.Value == nil {
+		if mode == StrictParse {
+			return fmt.Errorf("%w: %s: missing value", ErrSyntax, path)
+		}
+		n.Meta = Suspect{Reason: path + ": missing value", Meta: n.Meta}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package treeprint
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	one := tree.AddMetaBranch(1, "one")
+	one.AddNode("a")
+	one.AddBranch("b").AddNode("c")
+	tree.AddNode("two")
+
+	b, err := json.Marshal(tree)
+	assert.NoError(err)
+	assert.Equal(`{"value":".","children":[{"value":"one","meta":1,"children":[{"value":"a"},{"value":"b","children":[{"value":"c"}]}]},{"value":"two"}]}`, string(b))
+
+	b, err = json.Marshal(New())
+	assert.NoError(err)
+	assert.Equal(`{"value":"."}`, string(b))
+
+	_, err = json.Marshal(NewWithRoot(func() {}))
+	assert.Error(err)
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	one := tree.AddMetaBranch(1, "one")
+	one.AddNode("a")
+	one.AddBranch("b").AddNode(2.5)
+	tree.AddNode("two")
+	b, err := json.Marshal(tree)
+	assert.NoError(err)
+
+	decoded := NewWithRoot("old")
+	decoded.AddNode("dropped")
+	assert.NoError(json.Unmarshal(b, decoded))
+	assert.Equal(tree.String(), decoded.String())
+	assert.NoError(decoded.Validate())
+	assert.Equal(json.Number("1"), decoded.(*Node).Nodes[0].Meta)
+
+	assert.Error(json.Unmarshal([]byte(`{"children":1}`), decoded))
+}
+
+func TestParseJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	doc := `{"value": ".", "children": [{"value": 1, "meta": "m"}, {"meta": "x", "extra": true}, {"value": null}]}`
+	_, err := ParseJSON(strings.NewReader(doc), StrictParse)
+	assert.EqualError(err, `treeprint: json: unknown field "extra"`)
+	_, err = ParseJSON(strings.NewReader(`{"value": ".", "children": [{"meta": "x"}]}`), StrictParse)
+	assert.ErrorIs(err, ErrSyntax)
+	assert.EqualError(err, "treeprint: syntax error: $.children[0]: missing value")
+
+	tree, err := ParseJSON(strings.NewReader(doc), LenientParse)
+	assert.NoError(err)
+	assert.Equal(`.
+├── [m]  1
+├── [suspect: $.children[1]: missing value]  <nil>
+└── <nil>
+`, tree.String())
+	assert.Equal("x", tree.(*Node).Nodes[1].Meta.(Suspect).Meta)
+	assert.Equal(json.Number("1"), tree.(*Node).Nodes[0].Value)
+
+	_, err = ParseJSON(strings.NewReader(`{"value": [}`), LenientParse)
+	assert.Error(err)
+}
@@ -0,0 +1,69 @@
+package treeprint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lazyDir produces an endless hierarchy, counting the calls to its children funcs.
+func lazyDir(name string, calls *int) *Node {
+	n := &Node{Value: name}
+	n.SetChildrenFunc(func() []*Node {
+		*calls++
+		return []*Node{
+			lazyDir(name+"/a", calls),
+			lazyDir(name+"/b", calls),
+		}
+	})
+	return n
+}
+
+func TestLazyChildren(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	tree := lazyDir("root", &calls)
+	actual := tree.Print(NewPrinter(WithMaxDepth(2)))
+	expected := `root
+├── root/a
+│   ├── root/a/a
+│   └── root/a/b
+└── root/b
+    ├── root/b/a
+    └── root/b/b`
+	assert.Equal(expected, actual)
+	assert.Equal(3, calls)
+
+	// already resolved children are not produced again
+	tree.Print(NewPrinter(WithMaxDepth(2)))
+	assert.Equal(3, calls)
+
+	var visited []Value
+	tree.Walk(func(item *Node) WalkAction {
+		visited = append(visited, item.Value)
+		if len(visited) == 4 {
+			return WalkStop
+		}
+		return WalkContinue
+	})
+	assert.Equal([]Value{"root/a", "root/a/a", "root/a/a/a", "root/a/a/a/a"}, visited)
+	assert.Equal(5, calls)
+	assert.NoError(tree.Validate())
+}
+
+func TestMaxDepth(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("one").AddBranch("two").AddNode("three")
+	tree.AddNode("four")
+	for depth, expected := range []string{
+		".\n├── one\n│   └── two\n│       └── three\n└── four",
+		".\n├── one\n└── four",
+		".\n├── one\n│   └── two\n└── four",
+	} {
+		assert.Equal(expected, tree.Print(NewPrinter(WithMaxDepth(depth))), fmt.Sprint(depth))
+	}
+}
@@ -0,0 +1,54 @@
+package treeprint
+
+import (
+	"fmt"
+	"io"
+)
+
+// WithLevelMetaFuncs formats the meta values of the nodes of depth i with funcs[i],
+// and those of the deeper nodes with the last one, the depth being 0 for the Node
+// the rendering starts from. A nil function hides the meta values of its depth,
+// so that WithLevelMetaFuncs(BracketMeta("(", ")"), BracketMeta("[", "]"), nil)
+// shows the metas of the first two levels only. It takes precedence over WithMetaFunc.
+func WithLevelMetaFuncs(funcs ...PrintMetaFunc) Option {
+	return func(p *PrinterOptions) {
+		p.levelMetaFuncs = funcs
+	}
+}
+
+// BracketMeta returns a PrintMetaFunc formatting the meta values with %v between open and close,
+// the default format being BracketMeta("[", "]").
+func BracketMeta(open, close string) PrintMetaFunc {
+	return func(m MetaValue, w io.Writer) {
+		s, ok := formatMeta(m)
+		if !ok {
+			s, ok = m.(string)
+		}
+		if ok {
+			io.WriteString(w, open)
+			io.WriteString(w, s)
+			io.WriteString(w, close)
+			return
+		}
+		fmt.Fprintf(w, "%s%v%s", open, m, close)
+	}
+}
+
+// metaFuncAt returns the function formatting the meta values of the nodes of the depth,
+// or nil if they are hidden.
+func (p PrinterOptions) metaFuncAt(depth int) PrintMetaFunc {
+	if n := len(p.levelMetaFuncs); n > 0 {
+		if depth >= n {
+			depth = n - 1
+		}
+		return p.levelMetaFuncs[depth]
+	}
+	return p.metaFunc
+}
+
+// levelDependent reports whether the line of a Node depends on its depth,
+// and not only on its own content and on whether it is the last of its siblings.
+func (p PrinterOptions) levelDependent() bool {
+	return p.maxDepth > 0 || len(p.levelMetaFuncs) > 0 || p.renderHook != nil || len(p.styleRules) > 0 ||
+		p.levelMarkers
+}
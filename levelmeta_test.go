@@ -0,0 +1,49 @@
+package treeprint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelMetaFuncs(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewWithRoot("cluster")
+	tree.SetMetaValue("eu")
+	ns := tree.AddMetaBranch("prod", "namespace")
+	pod := ns.AddMetaBranch(3, "pod")
+	pod.AddMetaNode("ready", "container")
+
+	f := NewPrinter(WithLevelMetaFuncs(nil, BracketMeta("(", ")"), BracketMeta("[", "]"), nil))
+	want := `cluster
+└── (prod)  namespace
+    └── [3]  pod
+        └── container
+`
+	assert.Equal(want, string(tree.Bytes(f)))
+	assert.Less(tree.(*Node).EstimateSize(f), tree.(*Node).EstimateSize(NewPrinter()))
+
+	// the last function formats the deeper levels
+	f = NewPrinter(WithLevelMetaFuncs(BracketMeta("<", ">"), BracketMeta("{", "}")))
+	assert.Equal(`<eu>  cluster
+└── {prod}  namespace
+    └── {3}  pod
+        └── {ready}  container
+`, string(tree.Bytes(f)))
+
+	var buf bytes.Buffer
+	_, err := NewIncrementalPrinter(WithLevelMetaFuncs(nil, defaultPrintMeta, nil)).Render(&buf, tree.(*Node))
+	assert.NoError(err)
+	assert.Equal(`cluster
+└── [prod]  namespace
+    └── pod
+        └── container
+`, buf.String())
+
+	// the depth counts from the rendered Node, cached values included
+	f = NewPrinter(WithLevelMetaFuncs(nil, defaultPrintMeta), WithValueCache(NewValueCache()))
+	assert.Contains(string(tree.Bytes(f)), "└── [prod]  namespace\n")
+	assert.Equal("namespace\n└── [3]  pod\n    └── [ready]  container\n", string(ns.Detach().Bytes(f)))
+}
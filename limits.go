@@ -0,0 +1,75 @@
+package treeprint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrLimitExceeded is reported by strict limits, see WithStrictLimits.
+var ErrLimitExceeded = errors.New("treeprint: render limit exceeded")
+
+// LimitError tells which render limit the tree exceeded, it wraps ErrLimitExceeded.
+type LimitError struct {
+	// Limit is either "depth", "nodes" or "bytes".
+	Limit string
+	// Max is the value of the exceeded limit.
+	Max int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("treeprint: %s limit %d exceeded", e.Limit, e.Max)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// WithMaxBytes stops the rendering before the line that would take the output past
+// the given number of bytes, and ends the output with a line telling it was truncated.
+// The truncation line itself is not counted. Zero means no limit.
+func WithMaxBytes(n int) Option {
+	return func(p *PrinterOptions) {
+		p.maxBytes = n
+	}
+}
+
+// WithStrictLimits makes exceeding any of WithMaxDepth, WithMaxNodes and WithMaxBytes
+// an error instead of a truncation: the rendering stops without the truncation line
+// and PrintTo returns a *LimitError. The depth is exceeded by a Node at the maximum
+// depth having children, lazy ones included. It is meant for trees derived from
+// untrusted input, where silently cut output is worse than none.
+func WithStrictLimits() Option {
+	return func(p *PrinterOptions) {
+		p.strictLimits = true
+	}
+}
+
+// limited tells whether the limits span the whole output rather than a single subtree.
+func (p PrinterOptions) limited() bool {
+	return p.maxNodes > 0 || p.maxBytes > 0 || p.strictLimits
+}
+
+// truncate stops the rendering, ending the output with the marker line
+// or failing with err when the limits are strict.
+func (p *printer) truncate(err *LimitError, marker string) {
+	if p.pf.strictLimits {
+		p.err = err
+		p.stop = true
+		return
+	}
+	p.unlimited = true
+	io.WriteString(p, marker)
+	p.stop = true
+}
+
+// belowMaxDepth tells whether the children of a Node at the level are to be rendered.
+func (p *printer) belowMaxDepth(node *Node, level int) bool {
+	if p.pf.maxDepth <= 0 || level+2 <= p.pf.maxDepth {
+		return true
+	}
+	if p.pf.strictLimits && (len(node.Nodes) > 0 || node.lazy.pending()) {
+		p.truncate(&LimitError{Limit: "depth", Max: p.pf.maxDepth}, "")
+	}
+	return false
+}
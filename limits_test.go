@@ -0,0 +1,64 @@
+package treeprint
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func limitsTree() Tree {
+	tree := New()
+	one := tree.AddBranch("one")
+	one.AddNode("a")
+	one.AddBranch("b").AddNode("c")
+	tree.AddNode("two")
+	return tree
+}
+
+func TestMaxBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := limitsTree()
+	assert.Equal(`.
+├── one
+│   ├── a
+… output truncated (byte limit reached)`, tree.Print(NewPrinter(WithMaxBytes(40))))
+	assert.Equal(tree.String(), string(tree.Bytes(NewPrinter(WithMaxBytes(len(tree.String()))))))
+	assert.Equal("… output truncated (byte limit reached)", tree.Print(NewPrinter(WithMaxBytes(1))))
+}
+
+func TestStrictLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := limitsTree()
+	for _, tc := range []struct {
+		option Option
+		limit  string
+		output string
+	}{
+		{WithMaxDepth(2), "depth", ".\n├── one\n│   ├── a\n│   └── b\n"},
+		{WithMaxNodes(1), "nodes", ".\n├── one\n"},
+		{WithMaxBytes(20), "bytes", ".\n├── one\n"},
+	} {
+		buf := new(bytes.Buffer)
+		n, err := tree.PrintTo(buf, NewPrinter(tc.option, WithStrictLimits()))
+		assert.True(errors.Is(err, ErrLimitExceeded))
+		var limitErr *LimitError
+		if assert.True(errors.As(err, &limitErr)) {
+			assert.Equal(tc.limit, limitErr.Limit)
+		}
+		assert.Equal(tc.output, buf.String())
+		assert.Equal(int64(buf.Len()), n)
+
+		buf.Reset()
+		_, err = tree.PrintToParallel(buf, NewPrinter(tc.option, WithStrictLimits()), 2)
+		assert.True(errors.Is(err, ErrLimitExceeded))
+		assert.Equal(tc.output, buf.String())
+	}
+
+	_, err := tree.PrintTo(new(bytes.Buffer), NewPrinter(WithMaxDepth(3), WithStrictLimits()))
+	assert.NoError(err)
+	assert.EqualError(&LimitError{Limit: "nodes", Max: 3}, "treeprint: nodes limit 3 exceeded")
+}
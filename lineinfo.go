@@ -0,0 +1,60 @@
+package treeprint
+
+import (
+	"bytes"
+	"io"
+)
+
+// LineInfo locates the line of a Node in the rendered output, see PrintLineInfo.
+type LineInfo struct {
+	Node *Node
+	// Depth is 0 for the Node the rendering starts from, 1 for its children and so on.
+	Depth int
+	// Line is the number of the first line of the Node, counting from 0,
+	// and Lines the number of lines it takes, more than one for multiline values.
+	Line  int
+	Lines int
+	// Start and End are the byte offsets of the lines in the output, End excluded.
+	Start int64
+	End   int64
+}
+
+// PrintLineInfo renders the tree or subtree into w as PrintTo does, and returns where
+// the line of every rendered Node is in the output, in order, so that TUIs and editors
+// can map the lines back to the nodes without parsing the output.
+// The lines written by the printer itself, such as the truncation line, have no LineInfo.
+func (n *Node) PrintLineInfo(w io.Writer, f PrinterOptions) ([]LineInfo, error) {
+	if n == nil {
+		return nil, nil
+	}
+	var lines []LineInfo
+	cw := &lineCounter{w: w}
+	p := newPrinter(cw, f)
+	defer p.release()
+	p.onLine = func(node *Node, depth int, start, end int64) {
+		// the line of the Node is the last thing written
+		count := bytes.Count(p.line, []byte("\n"))
+		lines = append(lines, LineInfo{
+			Node:  node,
+			Depth: depth,
+			Line:  cw.lines - count,
+			Lines: count,
+			Start: start,
+			End:   end,
+		})
+	}
+	n.render(p)
+	return lines, p.err
+}
+
+// lineCounter counts the line breaks written through it.
+type lineCounter struct {
+	w     io.Writer
+	lines int
+}
+
+func (c *lineCounter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.lines += bytes.Count(b[:n], []byte("\n"))
+	return n, err
+}
@@ -0,0 +1,38 @@
+package treeprint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintLineInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	one := tree.AddBranch("one")
+	one.AddNode("a\nb")
+	tree.AddNode("two")
+
+	buf := new(bytes.Buffer)
+	lines, err := tree.PrintLineInfo(buf, NewPrinter(WithCodeFence("", "")))
+	assert.NoError(err)
+	out := buf.String()
+	assert.Equal("```\n.\n├── one\n│   └── a\n│       b\n└── two\n```\n", out)
+
+	assert.Len(lines, 4)
+	assert.Equal(LineInfo{Node: tree.(*Node), Depth: 0, Line: 1, Lines: 1, Start: 4, End: 6}, lines[0])
+	assert.Equal(one, lines[1].Node)
+	assert.Equal(2, lines[2].Depth)
+	assert.Equal(3, lines[2].Line)
+	assert.Equal(2, lines[2].Lines)
+	assert.Equal("│   └── a\n│       b\n", out[lines[2].Start:lines[2].End])
+	assert.Equal("└── two\n", out[lines[3].Start:lines[3].End])
+	assert.Equal(5, lines[3].Line)
+
+	// the nodes cut by the limits have no LineInfo
+	lines, err = tree.PrintLineInfo(new(bytes.Buffer), NewPrinter(WithMaxNodes(1)))
+	assert.NoError(err)
+	assert.Len(lines, 2)
+}
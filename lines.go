@@ -0,0 +1,61 @@
+package treeprint
+
+import "bytes"
+
+// LineFunc receives the rendered output a line at a time, without the line break.
+// Returning an error stops the rendering.
+type LineFunc func(line string) error
+
+// PrintLines renders the tree or subtree calling fn once per line, so that line-oriented
+// sinks, such as loggers or streams, get a record per line. Writing every line
+// separately into an io.Writer is done by a fn like:
+//
+//	func(line string) error {
+//		_, err := io.WriteString(w, line+"\n")
+//		return err
+//	}
+//
+// The first error returned by fn is returned.
+func (n *Node) PrintLines(f PrinterOptions, fn LineFunc) error {
+	w := &lineWriter{fn: fn}
+	if _, err := n.PrintTo(w, f); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+// lineWriter splits the written bytes into lines, keeping the incomplete last one.
+type lineWriter struct {
+	fn      LineFunc
+	partial []byte
+}
+
+func (w *lineWriter) Write(b []byte) (int, error) {
+	n := len(b)
+	for {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			w.partial = append(w.partial, b...)
+			return n, nil
+		}
+		line := b[:i]
+		if len(w.partial) > 0 {
+			line = append(w.partial, line...)
+			w.partial = w.partial[:0]
+		}
+		if err := w.fn(string(line)); err != nil {
+			return n - len(b), err
+		}
+		b = b[i+1:]
+	}
+}
+
+// flush passes the last line if it lacks a line break.
+func (w *lineWriter) flush() error {
+	if len(w.partial) == 0 {
+		return nil
+	}
+	line := string(w.partial)
+	w.partial = w.partial[:0]
+	return w.fn(line)
+}
@@ -0,0 +1,40 @@
+package treeprint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintLines(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("one").AddNode("a\nb")
+	tree.AddNode("two")
+
+	var lines []string
+	collect := func(line string) error {
+		lines = append(lines, line)
+		return nil
+	}
+	assert.NoError(tree.PrintLines(NewPrinter(), collect))
+	assert.Equal([]string{".", "├── one", "│   └── a", "│       b", "└── two"}, lines)
+
+	lines = nil
+	assert.NoError(tree.PrintLines(NewPrinter(WithCodeFence("", "Caption")), collect))
+	assert.Equal([]string{"Caption", "", "```", "."}, lines[:4])
+
+	errStop := errors.New("stop")
+	calls := 0
+	err := tree.PrintLines(NewPrinter(), func(line string) error {
+		calls++
+		if calls == 2 {
+			return errStop
+		}
+		return nil
+	})
+	assert.Equal(errStop, err)
+	assert.Equal(2, calls)
+}
@@ -0,0 +1,88 @@
+package treeprint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// LiveRenderer repaints a tree in place on a terminal as it changes, for long-running
+// commands showing the progress of a hierarchy of steps. Every Update moves the cursor
+// back up over the previous render and overwrites it, clearing what's left over.
+//
+// When the output is not a terminal, or TERM is "dumb", the renders are appended
+// to the output instead, and only when they differ from the previous one, so logs
+// and pipes get readable snapshots rather than escape sequences.
+//
+// The lines are expected to fit the terminal width, wrapped lines are not repainted correctly.
+// A LiveRenderer is safe for concurrent use.
+type LiveRenderer struct {
+	mu    sync.Mutex
+	w     io.Writer
+	pf    PrinterOptions
+	tty   bool
+	lines int
+	prev  []byte
+	buf   bytes.Buffer
+	out   bytes.Buffer
+}
+
+// NewLiveRenderer creates a LiveRenderer writing to w with the given printer options.
+func NewLiveRenderer(w io.Writer, options ...Option) *LiveRenderer {
+	return &LiveRenderer{
+		w:   w,
+		pf:  NewPrinter(options...),
+		tty: isTerminal(w),
+	}
+}
+
+// Update renders the tree, replacing the previous render on a terminal.
+// It returns the first write error encountered.
+func (r *LiveRenderer) Update(t Tree) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buf.Reset()
+	if _, err := t.PrintTo(&r.buf, r.pf); err != nil {
+		return err
+	}
+	b := r.buf.Bytes()
+	if bytes.Equal(b, r.prev) {
+		return nil
+	}
+	r.prev = append(r.prev[:0], b...)
+	if !r.tty {
+		_, err := r.w.Write(b)
+		return err
+	}
+	r.out.Reset()
+	if r.lines > 0 {
+		// back to the start of the first line of the previous render
+		fmt.Fprintf(&r.out, "\r\x1b[%dA", r.lines)
+	}
+	r.lines = 0
+	for _, line := range bytes.SplitAfter(b, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		r.out.Write(bytes.TrimSuffix(line, []byte("\n")))
+		// clear the remains of a longer line rendered before
+		r.out.WriteString("\x1b[K\n")
+		r.lines++
+	}
+	// clear the lines left over from a longer render
+	r.out.WriteString("\x1b[J")
+	_, err := r.w.Write(r.out.Bytes())
+	return err
+}
+
+// isTerminal reports whether w is a terminal able to handle the cursor movements.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
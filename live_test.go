@@ -0,0 +1,65 @@
+package treeprint
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveRenderer(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	r := NewLiveRenderer(buf, WithCheckboxes(false))
+	r.tty = true
+
+	tree := NewWithRoot("deploy")
+	build := tree.AddBranch("build")
+	build.SetStatus(InProgress)
+	push := tree.AddBranch("push")
+	push.SetStatus(Todo)
+
+	assert.NoError(r.Update(tree))
+	assert.Equal("deploy\x1b[K\n├── [~] build\x1b[K\n└── [ ] push\x1b[K\n\x1b[J", buf.String())
+
+	buf.Reset()
+	build.SetStatus(Done)
+	assert.NoError(r.Update(tree))
+	assert.Equal("\r\x1b[3A"+
+		"deploy\x1b[K\n├── [x] build\x1b[K\n└── [ ] push\x1b[K\n\x1b[J", buf.String())
+
+	// unchanged renders are not repainted
+	buf.Reset()
+	assert.NoError(r.Update(tree))
+	assert.Empty(buf.String())
+
+	buf.Reset()
+	push.Detach()
+	assert.NoError(r.Update(tree))
+	assert.Equal("\r\x1b[3A"+
+		"deploy\x1b[K\n└── [x] build\x1b[K\n\x1b[J", buf.String())
+}
+
+func TestLiveRendererAppendOnly(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	r := NewLiveRenderer(buf)
+	assert.False(r.tty)
+
+	tree := NewWithRoot("deploy")
+	tree.AddNode("build")
+	assert.NoError(r.Update(tree))
+	assert.NoError(r.Update(tree))
+	tree.AddNode("push")
+	assert.NoError(r.Update(tree))
+	assert.Equal("deploy\n└── build\n"+
+		"deploy\n├── build\n└── push\n", buf.String())
+
+	f, err := os.CreateTemp(t.TempDir(), "live")
+	assert.NoError(err)
+	defer f.Close()
+	assert.False(isTerminal(f))
+}
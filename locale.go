@@ -0,0 +1,83 @@
+package treeprint
+
+import "fmt"
+
+// Collator compares strings by the rules of a language, as golang.org/x/text/collate.Collator does.
+type Collator interface {
+	// CompareString returns -1, 0 or 1 as a sorts before, along with or after b.
+	CompareString(a, b string) int
+}
+
+// Collated orders the nodes by their value, compared as strings by c,
+// so that the children are sorted the way the users of a non-English CLI expect.
+func Collated(c Collator) LessFunc {
+	return func(a, b *Node) bool {
+		return c.CompareString(sprint(a.Value), sprint(b.Value)) < 0
+	}
+}
+
+// Message identifies a text generated by treeprint itself, see WithMessages.
+type Message int
+
+const (
+	// NodesOmitted is the line ending an output cut by WithMaxNodes, n being the number of nodes left out.
+	NodesOmitted Message = iota
+	// BytesTruncated is the line ending an output cut by WithMaxBytes, n being the limit.
+	BytesTruncated
+	// Cycle is appended as is to the line of a Node closing a cycle, see CycleMarker.
+	Cycle
+	// MoreChildren follows PlaceholderMarker in the placeholder ending a branch capped
+	// by WithMaxChildren, n being the number of children left out.
+	MoreChildren
+	// Level starts the line of a Node rendered with WithLevelMarkers, n being its depth.
+	Level
+)
+
+// MessageFunc function type for producing the generated texts, they are written without a line break.
+type MessageFunc func(m Message, n int) string
+
+// DefaultMessage returns the English texts used unless WithMessages or WithBuildMessages is set.
+func DefaultMessage(m Message, n int) string {
+	switch m {
+	case NodesOmitted:
+		return fmt.Sprintf("… output truncated (%d nodes omitted)", n)
+	case BytesTruncated:
+		return "… output truncated (byte limit reached)"
+	case Cycle:
+		return CycleMarker
+	case MoreChildren:
+		return fmt.Sprintf("and %d more", n)
+	case Level:
+		return fmt.Sprintf("level %d: ", n)
+	}
+	return ""
+}
+
+// WithMessages produces the texts the printer generates with fn instead of DefaultMessage,
+// so that the output of a localized CLI doesn't mix languages.
+func WithMessages(fn MessageFunc) Option {
+	return func(p *PrinterOptions) {
+		p.messages = fn
+	}
+}
+
+// WithBuildMessages produces the texts the builders generate with fn instead of DefaultMessage.
+func WithBuildMessages(fn MessageFunc) BuildOption {
+	return func(b *buildOptions) {
+		b.messages = fn
+	}
+}
+
+func (p PrinterOptions) message(m Message, n int) string {
+	if p.messages == nil {
+		return DefaultMessage(m, n)
+	}
+	return p.messages(m, n)
+}
+
+func (b *buildOptions) message(m Message, n int) string {
+	if b.messages == nil {
+		return DefaultMessage(m, n)
+	}
+	return b.messages(m, n)
+}
@@ -0,0 +1,44 @@
+package treeprint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+func TestCollated(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	for _, v := range []string{"zebra", "Äpfel", "apfel", "Zucker"} {
+		tree.AddNode(v)
+	}
+	f := NewPrinter(WithSort(Collated(collate.New(language.German))))
+	assert.Equal(".\n├── apfel\n├── Äpfel\n├── zebra\n└── Zucker\n", string(tree.Bytes(f)))
+}
+
+func TestWithMessages(t *testing.T) {
+	assert := assert.New(t)
+
+	german := func(m Message, n int) string {
+		switch m {
+		case NodesOmitted:
+			return fmt.Sprintf("… Ausgabe gekürzt (%d Knoten ausgelassen)", n)
+		case MoreChildren:
+			return fmt.Sprintf("und %d weitere", n)
+		}
+		return DefaultMessage(m, n)
+	}
+
+	tree := New()
+	tree.AddNode("a").AddNode("b").AddNode("c")
+	assert.Equal(".\n├── a\n… Ausgabe gekürzt (2 Knoten ausgelassen)\n",
+		string(tree.Bytes(NewPrinter(WithMaxNodes(1), WithMessages(german)))))
+	assert.Equal(".\n├── a\n… output truncated (2 nodes omitted)\n", string(tree.Bytes(NewPrinter(WithMaxNodes(1)))))
+
+	built := FromPaths([]string{"a", "b", "c"}, "/", WithMaxChildren(1), WithBuildMessages(german))
+	assert.Equal(".\n├── a\n└── … und 2 weitere\n", built.String())
+}
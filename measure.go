@@ -0,0 +1,178 @@
+package treeprint
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// MeasureFunc function type for measuring the width of a rendered text, in columns
+// or in whatever unit the output is laid out with. It is given single lines.
+type MeasureFunc func(s string) int
+
+// WithMeasureFunc measures the rendered text with fn instead of DisplayWidth
+// everywhere widths matter, so that applications using custom fonts, tabs
+// or embedded markup can lay out the output with accurate widths.
+func WithMeasureFunc(fn MeasureFunc) Option {
+	return func(p *PrinterOptions) {
+		p.measure = fn
+	}
+}
+
+// Measure returns the width of s as measured by the function set with WithMeasureFunc,
+// or DisplayWidth. A multiline s measures as its widest line.
+func (p PrinterOptions) Measure(s string) int {
+	measure := p.measure
+	if measure == nil {
+		measure = DisplayWidth
+	}
+	var max int
+	for {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			break
+		}
+		if w := measure(s[:i]); w > max {
+			max = w
+		}
+		s = s[i+1:]
+	}
+	if w := measure(s); w > max {
+		max = w
+	}
+	return max
+}
+
+// DisplayWidth returns the number of terminal columns s takes: the wide and fullwidth
+// characters take two columns, the combining marks, the formatting characters and
+// the ANSI escape sequences, such as the ones of Style, none, and the other characters one.
+func DisplayWidth(s string) int {
+	var w int
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		case isWide(r):
+			w += 2
+		default:
+			w++
+		}
+	}
+	return w
+}
+
+// escapeLen returns the length of the ANSI control sequence s starts with, or 0 if none.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		// the parameter and intermediate bytes are followed by a final byte
+		if c := s[i]; c >= 0x40 && c <= 0x7e {
+			return i + 1
+		} else if c < 0x20 || c > 0x3f {
+			return 0
+		}
+	}
+	return 0
+}
+
+func isWide(r rune) bool {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return true
+	}
+	return false
+}
+
+// Width returns the width of the widest line of the tree or subtree rendered
+// with the given printer options, as measured by PrinterOptions.Measure.
+func (n *Node) Width(f PrinterOptions) int {
+	var max int
+	_ = n.PrintLines(f, func(line string) error {
+		if w := f.Measure(line); w > max {
+			max = w
+		}
+		return nil
+	})
+	return max
+}
+
+// Metrics describes the output of a rendering, see Node.Measure.
+type Metrics struct {
+	// Lines is the number of lines of the output.
+	Lines int
+	// Width is the width of the widest line.
+	Width int
+	// LevelWidths holds the width of the widest line of the nodes of every depth,
+	// 0 being the depth of the Node the rendering starts from. The lines written
+	// by the printer itself, such as the truncation line, only count in Width.
+	LevelWidths []int
+}
+
+// Measure renders the tree or subtree with the given printer options without producing
+// any output and returns the size of the output, as measured by PrinterOptions.Measure,
+// so that callers can pick the options or allocate the screen space before rendering.
+func (n *Node) Measure(f PrinterOptions) Metrics {
+	var m Metrics
+	if n == nil {
+		return m
+	}
+	lw := &lineMeasurer{f: f}
+	p := newPrinter(lw, f)
+	defer p.release()
+	p.onLine = func(_ *Node, depth int, _, _ int64) {
+		for len(m.LevelWidths) <= depth {
+			m.LevelWidths = append(m.LevelWidths, 0)
+		}
+		// the line of the Node is the last thing written
+		if w := f.Measure(strings.TrimSuffix(string(p.line), "\n")); w > m.LevelWidths[depth] {
+			m.LevelWidths[depth] = w
+		}
+	}
+	n.render(p)
+	if len(lw.line) > 0 {
+		lw.endLine()
+	}
+	m.Lines, m.Width = lw.lines, lw.width
+	return m
+}
+
+// lineMeasurer counts and measures the lines written through it.
+type lineMeasurer struct {
+	f     PrinterOptions
+	line  []byte
+	lines int
+	width int
+}
+
+func (l *lineMeasurer) Write(b []byte) (int, error) {
+	n := len(b)
+	for {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			l.line = append(l.line, b...)
+			return n, nil
+		}
+		l.line = append(l.line, bytes.TrimSuffix(b[:i], []byte("\r"))...)
+		l.endLine()
+		b = b[i+1:]
+	}
+}
+
+// endLine counts and measures the pending line.
+func (l *lineMeasurer) endLine() {
+	l.lines++
+	if w := l.f.Measure(string(l.line)); w > l.width {
+		l.width = w
+	}
+	l.line = l.line[:0]
+}
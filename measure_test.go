@@ -0,0 +1,60 @@
+package treeprint
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasure(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(5, DisplayWidth("hello"))
+	assert.Equal(4, DisplayWidth("日本"))
+	assert.Equal(4, DisplayWidth("café"))
+	assert.Equal(4, DisplayWidth("\x1b[1;31mbold\x1b[0m"))
+	assert.Equal(2, DisplayWidth("\x1b["))
+	assert.Equal(0, DisplayWidth(""))
+
+	f := NewPrinter()
+	assert.Equal(6, f.Measure("ab\nabcdef\nabc"))
+
+	tree := New()
+	tree.AddBranch("one").AddNode("日本語")
+	tree.AddMetaNode("m", "two")
+	// "│   └── 日本語"
+	assert.Equal(4+4+6, tree.Width(f))
+
+	bytesWidth := NewPrinter(WithMeasureFunc(func(s string) int { return len(s) }))
+	assert.Equal(len("│   └── 日本語"), tree.Width(bytesWidth))
+	runes := NewPrinter(WithMeasureFunc(utf8.RuneCountInString))
+	// "├── [m]  two"
+	assert.Equal(12, tree.Width(runes))
+}
+
+func TestNodeMeasure(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	one := tree.AddBranch("one")
+	one.AddNode("日本語")
+	one.AddNode("a\nlonger line")
+	tree.AddNode("two")
+
+	f := NewPrinter()
+	assert.Equal(Metrics{
+		Lines:       6,
+		Width:       4 + 4 + 11,
+		LevelWidths: []int{1, 4 + 3, 4 + 4 + 11},
+	}, tree.Measure(f))
+	assert.Equal(tree.Width(f), tree.Measure(f).Width)
+
+	limited := NewPrinter(WithMaxNodes(1))
+	m := tree.Measure(limited)
+	assert.Equal(len(strings.Split(strings.TrimSuffix(tree.String(), "\n"), "\n")), tree.Measure(f).Lines)
+	assert.Equal(len(strings.Split(strings.TrimSuffix(string(tree.Bytes(limited)), "\n"), "\n")), m.Lines)
+	assert.Len(m.LevelWidths, 2)
+	assert.Equal(Metrics{}, (*Node)(nil).Measure(f))
+}
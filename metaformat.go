@@ -0,0 +1,52 @@
+package treeprint
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	metaFormattersMu sync.Mutex
+	// metaFormatters holds a map[reflect.Type]func(MetaValue) string, replaced on every change
+	// so that the rendering reads it without locking
+	metaFormatters atomic.Value
+)
+
+// RegisterMetaFormatter registers f for formatting the meta values of type T in the default
+// meta format and in BracketMeta, where they are shown as f returns them between the brackets.
+// It saves custom PrintMetaFuncs switching over the meta types, as for durations,
+// sizes or enums. T is matched against the dynamic type of the meta values, so an interface
+// type never matches. A nil f removes the formatter of T. Formatters are meant to be registered
+// at init time, values already held by a ValueCache are not formatted again.
+func RegisterMetaFormatter[T any](f func(T) string) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	metaFormattersMu.Lock()
+	defer metaFormattersMu.Unlock()
+	old, _ := metaFormatters.Load().(map[reflect.Type]func(MetaValue) string)
+	formatters := make(map[reflect.Type]func(MetaValue) string, len(old)+1)
+	for t, fn := range old {
+		formatters[t] = fn
+	}
+	if f == nil {
+		delete(formatters, typ)
+	} else {
+		formatters[typ] = func(m MetaValue) string {
+			return f(m.(T))
+		}
+	}
+	metaFormatters.Store(formatters)
+}
+
+// formatMeta formats m with the formatter registered for its type, if any.
+func formatMeta(m MetaValue) (string, bool) {
+	formatters, _ := metaFormatters.Load().(map[reflect.Type]func(MetaValue) string)
+	if len(formatters) == 0 {
+		return "", false
+	}
+	f, ok := formatters[reflect.TypeOf(m)]
+	if !ok {
+		return "", false
+	}
+	return f(m), true
+}
@@ -0,0 +1,41 @@
+package treeprint
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLevel int
+
+func TestRegisterMetaFormatter(t *testing.T) {
+	assert := assert.New(t)
+
+	RegisterMetaFormatter(func(d time.Duration) string { return d.Round(time.Second).String() })
+	RegisterMetaFormatter(func(l testLevel) string { return [...]string{"low", "high"}[l] })
+	defer RegisterMetaFormatter[time.Duration](nil)
+	defer RegisterMetaFormatter[testLevel](nil)
+	// interface types never match the dynamic type of a meta
+	RegisterMetaFormatter(func(s fmt.Stringer) string { return "stringer" })
+	defer RegisterMetaFormatter[fmt.Stringer](nil)
+
+	tree := New()
+	tree.AddMetaNode(1500*time.Millisecond, "build")
+	tree.AddMetaNode(testLevel(1), "alert")
+	tree.AddMetaNode(3, "other")
+	assert.Equal(`.
+├── [2s]  build
+├── [high]  alert
+└── [3]  other
+`, tree.String())
+	assert.Equal(`.
+├── (2s)  build
+├── (high)  alert
+└── (3)  other
+`, tree.Print(NewPrinter(WithLevelMetaFuncs(BracketMeta("(", ")"))))+"\n")
+
+	RegisterMetaFormatter[testLevel](nil)
+	assert.Contains(tree.String(), "[1]  alert")
+}
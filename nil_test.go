@@ -0,0 +1,77 @@
+package treeprint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNilNode(t *testing.T) {
+	assert := assert.New(t)
+
+	var n *Node
+	assert.NotPanics(func() {
+		n.AddNode("a").AddBranch("b").AddMetaNode(1, "c")
+		n.SetValue("v")
+		n.SetMetaValue("m")
+		n.Prune(func(*Node) bool { return true })
+		n.VisitAll(func(*Node) { t.Fatal("visited a nil Node") })
+		n.VisitBFS(func(*Node, int) { t.Fatal("visited a nil Node") })
+		n.MarkDirty()
+		n.Intern(NewInterner())
+	})
+	assert.Equal("", n.String())
+	assert.Equal("", n.Print(NewPrinter()))
+	assert.Nil(n.Bytes(NewPrinter()))
+	assert.Nil(n.RenderLines(NewPrinter(), 0, 10))
+	assert.Equal("<nil>", fmt.Sprintf("%v", n))
+	assert.Equal(0, n.ChildCount())
+	assert.Equal(-1, n.Index())
+	assert.Nil(n.FindByValue("a"))
+	assert.Nil(n.FindLastNode())
+	assert.Nil(n.LastNode())
+	assert.Nil(n.NodesAtDepth(1))
+	assert.Nil(n.Extract(func(*Node) bool { return true }))
+	assert.NoError(n.Validate())
+
+	b, err := n.MarshalJSON()
+	assert.NoError(err)
+	assert.Equal("null", string(b))
+
+	_, err = n.AddBranchE("a")
+	assert.ErrorIs(err, ErrNilNode)
+	_, err = n.FindByPathE("a")
+	assert.ErrorIs(err, ErrNilNode)
+	_, err = New().AddTreeE(n)
+	assert.ErrorIs(err, ErrNilNode)
+	_, err = New().(*Node).MoveToE(nil)
+	assert.ErrorIs(err, ErrNilNode)
+
+	frozen := n.Freeze()
+	assert.NotPanics(func() {
+		assert.Nil(frozen.Value())
+		assert.Nil(frozen.Meta())
+		assert.Empty(frozen.Children())
+		assert.Nil(frozen.FindByValue("a"))
+		assert.Equal("", frozen.String())
+		assert.Equal(0, frozen.ChildCount())
+		frozen.VisitAll(func(ImmutableTree) { t.Fatal("visited a nil Node") })
+	})
+}
+
+func TestFindChaining(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("a")
+	assert.NotPanics(func() {
+		tree.FindNodeByValue("a").AddNode("b")
+		tree.FindNodeByValue("x").AddNode("y")
+		tree.FindNodeByMeta("m").AddNode("y")
+		tree.FindNodeByValue("b").LastNode().AddNode("y")
+	})
+	assert.Equal(".\n└── a\n    └── b\n", tree.String())
+	assert.Nil(tree.FindNodeByValue("x"))
+	assert.Equal("b", tree.FindNodeByValue("a").LastNode().Value)
+}
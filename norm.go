@@ -0,0 +1,28 @@
+package treeprint
+
+import "golang.org/x/text/unicode/norm"
+
+// WithNormalization normalizes the rendered metas and values to the given Unicode
+// normalization form, usually norm.NFC, so that a character written either precomposed
+// or with combining characters renders, measures and compares the same.
+// Exporters measuring or writing values on their own can apply the same
+// normalization through PrinterOptions.Normalize.
+func WithNormalization(form norm.Form) Option {
+	return func(p *PrinterOptions) {
+		p.normalize = true
+		p.normForm = form
+	}
+}
+
+// Normalize returns s normalized as set by WithNormalization, or s itself if unset.
+func (f PrinterOptions) Normalize(s string) string {
+	if !f.normalize {
+		return s
+	}
+	return f.normForm.String(s)
+}
+
+// appendNormalized appends src to dst, normalized as set by WithNormalization.
+func (f PrinterOptions) appendNormalized(dst, src []byte) []byte {
+	return f.normForm.Append(dst, src...)
+}
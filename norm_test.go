@@ -0,0 +1,25 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestNormalization(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddMetaNode("cre\u0300me", "cafe\u0301")
+	tree.AddNode("été")
+
+	assert.Equal(".\n├── [crème]  café\n└── été\n",
+		string(tree.Bytes(NewPrinter(WithNormalization(norm.NFC)))))
+	assert.Equal(".\n├── [cre\u0300me]  cafe\u0301\n└── e\u0301te\u0301\n",
+		string(tree.Bytes(NewPrinter(WithNormalization(norm.NFD)))))
+	assert.Equal(".\n├── [cre\u0300me]  cafe\u0301\n└── été\n", tree.String())
+
+	assert.Equal("café", NewPrinter(WithNormalization(norm.NFC)).Normalize("cafe\u0301"))
+	assert.Equal("cafe\u0301", NewPrinter().Normalize("cafe\u0301"))
+}
@@ -0,0 +1,121 @@
+package treeprint
+
+import "sync/atomic"
+
+// Observer holds the callbacks notified of the changes made to a tree, see Observe.
+// Any of them may be nil.
+type Observer struct {
+	// OnAdd is called after child is added to the children of parent.
+	OnAdd func(parent, child *Node)
+	// OnRemove is called after child is removed from the children of parent.
+	OnRemove func(parent, child *Node)
+	// OnSetValue is called after the value of the Node is changed from old.
+	OnSetValue func(n *Node, old Value)
+	// OnSetMeta is called after the meta value of the Node is changed from old.
+	OnSetMeta func(n *Node, old MetaValue)
+}
+
+// Observe registers the observer on the tree the Node belongs to, so that it is notified
+// of the nodes added and removed by the Add methods, AddTree, MoveTo, Detach, Promote
+// and Prune, and of the changes made by SetValue and SetMetaValue, anywhere in the tree.
+// Neither the changes made to the fields directly nor the lazy children are reported.
+// The observer stays with the root of the tree: once the root is added to another tree,
+// it is notified of nothing until the root is detached again.
+// The returned function unregisters the observer.
+func (n *Node) Observe(o Observer) (cancel func()) {
+	if n == nil {
+		return func() {}
+	}
+	root := n.root()
+	entry := &o
+	root.observers = append(root.observers, entry)
+	root.watch()
+	var once int32
+	return func() {
+		if !atomic.CompareAndSwapInt32(&once, 0, 1) {
+			return
+		}
+		for i, e := range root.observers {
+			if e == entry {
+				root.observers = append(root.observers[:i], root.observers[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// root returns the root of the tree the Node belongs to.
+func (n *Node) root() *Node {
+	for n.Root != nil {
+		n = n.Root
+	}
+	return n
+}
+
+// observed returns the observers of the tree the Node belongs to. The root is only
+// looked up for the watched nodes, so that the changes of the trees never observed
+// don't cost a walk up to their root.
+func (n *Node) observed() []*Observer {
+	if n == nil || !n.watched {
+		return nil
+	}
+	return n.root().observers
+}
+
+// adopt marks the subtree of child watched when it is attached to a watched Node,
+// so that its changes are reported to the observers of the tree.
+func (n *Node) adopt(child *Node) {
+	if n.watched && !child.watched {
+		child.watch()
+	}
+}
+
+// watch marks the Node and its materialized descendants watched,
+// the lazy children are marked once produced.
+func (n *Node) watch() {
+	seen := map[*Node]bool{n: true}
+	stack := []*Node{n}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		node.watched = true
+		for _, child := range node.Nodes {
+			if !seen[child] {
+				seen[child] = true
+				stack = append(stack, child)
+			}
+		}
+	}
+}
+
+func (n *Node) emitAdd(child *Node) {
+	for _, o := range n.observed() {
+		if o.OnAdd != nil {
+			o.OnAdd(n, child)
+		}
+	}
+}
+
+func (n *Node) emitRemove(child *Node) {
+	for _, o := range n.observed() {
+		if o.OnRemove != nil {
+			o.OnRemove(n, child)
+		}
+	}
+}
+
+func (n *Node) emitSetValue(old Value) {
+	for _, o := range n.observed() {
+		if o.OnSetValue != nil {
+			o.OnSetValue(n, old)
+		}
+	}
+}
+
+func (n *Node) emitSetMeta(old MetaValue) {
+	for _, o := range n.observed() {
+		if o.OnSetMeta != nil {
+			o.OnSetMeta(n, old)
+		}
+	}
+}
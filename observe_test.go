@@ -0,0 +1,114 @@
+package treeprint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserve(t *testing.T) {
+	assert := assert.New(t)
+
+	var events []string
+	observer := Observer{
+		OnAdd: func(parent, child *Node) {
+			events = append(events, fmt.Sprintf("add %v to %v", child.Value, parent.Value))
+		},
+		OnRemove: func(parent, child *Node) {
+			events = append(events, fmt.Sprintf("remove %v from %v", child.Value, parent.Value))
+		},
+		OnSetValue: func(n *Node, old Value) {
+			events = append(events, fmt.Sprintf("value %v to %v", old, n.Value))
+		},
+		OnSetMeta: func(n *Node, old MetaValue) {
+			events = append(events, fmt.Sprintf("meta %v to %v", old, n.Meta))
+		},
+	}
+
+	tree := New()
+	a := tree.AddBranch("a")
+	b := a.AddBranch("b")
+	// registered on the root through any Node of the tree
+	cancel := b.Observe(observer)
+
+	b.AddNode("c")
+	tree.AddMetaNode("m", "d")
+	b.SetValue("B")
+	b.SetMetaValue(1)
+	b.Promote()
+	other := NewWithRoot("other")
+	other.AddNode("e")
+	tree.AddTree(other)
+	other.(*Node).MoveTo(a)
+	tree.Prune(func(n *Node) bool {
+		return n.Value == "d"
+	})
+	b.Detach()
+	// the detached subtree is no longer observed
+	b.AddNode("f")
+	assert.Equal([]string{
+		"add c to b",
+		"add d to .",
+		"value b to B",
+		"meta <nil> to 1",
+		"remove B from a",
+		"add B to .",
+		"add other to .",
+		"remove other from .",
+		"add other to a",
+		"remove d from .",
+		"remove B from .",
+	}, events)
+
+	events = nil
+	cancel()
+	cancel()
+	tree.AddNode("g")
+	assert.Empty(events)
+	assert.Empty(tree.(*Node).observers)
+
+	var nilNode *Node
+	nilNode.Observe(observer)()
+}
+
+func TestObservePerTree(t *testing.T) {
+	assert := assert.New(t)
+
+	var added []Value
+	observed, other := New(), New()
+	cancel := observed.Observe(Observer{OnAdd: func(_, child *Node) {
+		added = append(added, child.Value)
+	}})
+	other.AddNode("a")
+	observed.AddNode("b")
+	assert.Equal([]Value{"b"}, added)
+	assert.Empty(other.(*Node).observed(), "only the observed tree has observers to notify")
+
+	cancel()
+	observed.AddNode("c")
+	assert.Equal([]Value{"b"}, added)
+	assert.Empty(observed.(*Node).observed())
+}
+
+func TestObserveAttached(t *testing.T) {
+	assert := assert.New(t)
+
+	deep := New().(*Node)
+	branch := Tree(deep)
+	for i := 0; i < 100; i++ {
+		branch = branch.AddBranch(i)
+	}
+	assert.False(branch.(*Node).watched, "the nodes of a tree never observed don't look up their root")
+
+	var added []Value
+	tree := New()
+	tree.Observe(Observer{OnAdd: func(_, child *Node) {
+		added = append(added, child.Value)
+	}})
+	sub := New().(*Node)
+	leaf := sub.AddBranch("a").AddBranch("b")
+	tree.AddTree(sub)
+	leaf.AddNode("c")
+	assert.Equal([]Value{".", "c"}, added, "the attached subtree is observed along with the tree")
+}
@@ -0,0 +1,72 @@
+package treeprint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// openAPIMethods are the operations of an OpenAPI path item, in the order they are shown.
+var openAPIMethods = []string{"get", "put", "post", "patch", "delete", "head", "options", "trace"}
+
+// FromOpenAPIPaths builds the tree of the "paths" map of an OpenAPI spec, decoded
+// from JSON or YAML into plain maps. The paths are split into their segments under
+// a "/" root, and the Node ending a path gets the HTTP methods of its operations
+// as the meta, as in "GET POST". The paths are sorted, and the path item entries
+// which are not operations, such as "parameters", are ignored.
+func FromOpenAPIPaths(paths map[string]interface{}) Tree {
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	root := &Node{Value: "/"}
+	index := make(map[*Node]map[string]*Node)
+	for _, path := range sorted {
+		node := root
+		for _, segment := range strings.Split(path, "/") {
+			if segment == "" {
+				continue
+			}
+			children := index[node]
+			if children == nil {
+				children = make(map[string]*Node)
+				index[node] = children
+			}
+			child, ok := children[segment]
+			if !ok {
+				child = node.newChild(nil, segment)
+				node.Nodes = append(node.Nodes, child)
+				children[segment] = child
+			}
+			node = child
+		}
+		if methods := openAPIOperations(paths[path]); len(methods) > 0 {
+			node.Meta = strings.Join(methods, " ")
+		}
+	}
+	return root
+}
+
+// openAPIOperations returns the HTTP methods of the operations of a path item.
+func openAPIOperations(item interface{}) []string {
+	keys := make(map[string]bool)
+	switch item := item.(type) {
+	case map[string]interface{}:
+		for k := range item {
+			keys[strings.ToLower(k)] = true
+		}
+	case map[interface{}]interface{}:
+		for k := range item {
+			keys[strings.ToLower(fmt.Sprint(k))] = true
+		}
+	}
+	var methods []string
+	for _, m := range openAPIMethods {
+		if keys[m] {
+			methods = append(methods, strings.ToUpper(m))
+		}
+	}
+	return methods
+}
@@ -0,0 +1,37 @@
+package treeprint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromOpenAPIPaths(t *testing.T) {
+	assert := assert.New(t)
+
+	var spec struct {
+		Paths map[string]interface{} `json:"paths"`
+	}
+	assert.NoError(json.Unmarshal([]byte(`{
+		"openapi": "3.0.0",
+		"paths": {
+			"/users/{id}": {"parameters": [], "get": {}, "delete": {}},
+			"/users": {"post": {}, "get": {}},
+			"/health": {"get": {}},
+			"/users/{id}/posts/{post}": {"patch": {}}
+		}
+	}`), &spec))
+
+	assert.Equal(`/
+├── [GET]  health
+└── [GET POST]  users
+    └── [GET DELETE]  {id}
+        └── posts
+            └── [PATCH]  {post}
+`, FromOpenAPIPaths(spec.Paths).String())
+
+	assert.Equal("[GET]  /\n", FromOpenAPIPaths(map[string]interface{}{
+		"/": map[interface{}]interface{}{"get": nil},
+	}).String())
+}
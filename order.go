@@ -0,0 +1,220 @@
+package treeprint
+
+import "context"
+
+// TraversalOrder defines the order in which WalkOrder visits the nodes,
+// so that custom orders (priority-based, zig-zag, ...) can be plugged in.
+type TraversalOrder interface {
+	// Traverse calls visit for the descendants of n, with n itself having depth 0.
+	// It must honour WalkStop and should honour WalkSkipChildren where the order allows it.
+	// It reports whether the traversal was stopped.
+	Traverse(n *Node, visit func(item *Node, depth int) WalkAction) bool
+}
+
+// TraversalOrderFunc is an adapter to allow the use of ordinary functions as TraversalOrder.
+type TraversalOrderFunc func(n *Node, visit func(item *Node, depth int) WalkAction) bool
+
+// Traverse calls f(n, visit).
+func (f TraversalOrderFunc) Traverse(n *Node, visit func(item *Node, depth int) WalkAction) bool {
+	return f(n, visit)
+}
+
+var (
+	// PreOrder visits a Node before its children, like Walk.
+	PreOrder TraversalOrder = TraversalOrderFunc(preOrder)
+	// PostOrder visits a Node after its children, like VisitPostOrder.
+	// WalkSkipChildren has no effect since children are already visited.
+	PostOrder TraversalOrder = TraversalOrderFunc(postOrderWalk)
+	// BreadthFirst visits the nodes level by level, like VisitBFS.
+	BreadthFirst TraversalOrder = TraversalOrderFunc(breadthFirst)
+	// BottomUp visits the nodes level by level starting from the deepest one, like VisitBottomUp.
+	// WalkSkipChildren has no effect since children are already visited.
+	BottomUp TraversalOrder = TraversalOrderFunc(bottomUp)
+)
+
+// WalkOrder calls fn for every descendant of n in the given order,
+// see TraversalOrder for the meaning of the returned actions.
+// It reports whether the traversal was stopped.
+func (n *Node) WalkOrder(order TraversalOrder, fn WalkFunc) bool {
+	return order.Traverse(n, func(item *Node, _ int) WalkAction {
+		return fn(item)
+	})
+}
+
+// WalkOrderContext is like WalkOrder, but checks ctx every few nodes and stops
+// the traversal once ctx is done, returning ctx.Err().
+func (n *Node) WalkOrderContext(ctx context.Context, order TraversalOrder, fn WalkFunc) error {
+	var (
+		count int
+		err   error
+	)
+	order.Traverse(n, func(item *Node, _ int) WalkAction {
+		if count%contextCheckInterval == 0 {
+			if err = ctx.Err(); err != nil {
+				return WalkStop
+			}
+		}
+		count++
+		return fn(item)
+	})
+	return err
+}
+
+// WalkContext is like Walk, but stops once ctx is done, returning ctx.Err().
+func (n *Node) WalkContext(ctx context.Context, fn WalkFunc) error {
+	return n.WalkOrderContext(ctx, PreOrder, fn)
+}
+
+// VisitAllContext is like VisitAll, but stops once ctx is done, returning ctx.Err().
+func (n *Node) VisitAllContext(ctx context.Context, fn NodeVisitor) error {
+	return n.WalkOrderContext(ctx, PreOrder, func(item *Node) WalkAction {
+		fn(item)
+		return WalkContinue
+	})
+}
+
+func preOrder(n *Node, visit func(item *Node, depth int) WalkAction) bool {
+	return walkNodes(n, func(item *Node, depth int, _ *Node) WalkAction {
+		return visit(item, depth)
+	})
+}
+
+// walkFrame holds the state of a single branch during depth-first traversal.
+type walkFrame struct {
+	parent *Node
+	i      int
+	depth  int
+}
+
+// walkNodes is the depth-first pre-order traversal all the other ones build upon.
+// It keeps an explicit stack instead of recursing, so that very deep trees
+// can't overflow the goroutine stack. A Node reached again from one of its own
+// descendants is visited, but its children are not, so that cycles are walked once.
+func walkNodes(n *Node, visit func(item *Node, depth int, parent *Node) WalkAction) bool {
+	if n == nil {
+		return false
+	}
+	n.children()
+	stack := []walkFrame{{parent: n, depth: 1}}
+	path := map[*Node]bool{n: true}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.parent.Nodes) {
+			delete(path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node, depth := top.parent.Nodes[top.i], top.depth
+		top.i++
+		switch visit(node, depth, top.parent) {
+		case WalkStop:
+			return true
+		case WalkSkipChildren:
+			continue
+		}
+		if !path[node] && len(node.children()) > 0 {
+			path[node] = true
+			stack = append(stack, walkFrame{parent: node, depth: depth + 1})
+		}
+	}
+	return false
+}
+
+func postOrderWalk(n *Node, visit func(item *Node, depth int) WalkAction) bool {
+	if n == nil {
+		return false
+	}
+	n.children()
+	stack := []walkFrame{{parent: n, depth: 1}}
+	// a Node reached again from one of its own descendants is visited as a leaf
+	path := map[*Node]bool{n: true}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i < len(top.parent.Nodes) {
+			node, depth := top.parent.Nodes[top.i], top.depth
+			top.i++
+			if !path[node] && len(node.children()) > 0 {
+				path[node] = true
+				stack = append(stack, walkFrame{parent: node, depth: depth + 1})
+			} else if visit(node, depth) == WalkStop {
+				return true
+			}
+			continue
+		}
+		// all the children are visited, so is the branch unless it's the starting Node
+		delete(path, top.parent)
+		stack = stack[:len(stack)-1]
+		if len(stack) > 0 && visit(top.parent, top.depth-1) == WalkStop {
+			return true
+		}
+	}
+	return false
+}
+
+func breadthFirst(n *Node, visit func(item *Node, depth int) WalkAction) bool {
+	if n == nil {
+		return false
+	}
+	// a Node reached again from one of its own descendants is visited, but its children are not
+	expanded := map[*Node]bool{n: true}
+	queue := make([]*branch, 0, len(n.children()))
+	for _, node := range n.Nodes {
+		queue = append(queue, &branch{node: node, depth: 1})
+	}
+	for len(queue) > 0 {
+		b := queue[0]
+		queue = queue[1:]
+		switch visit(b.node, b.depth) {
+		case WalkStop:
+			return true
+		case WalkSkipChildren:
+			continue
+		}
+		if b.cycles(n, expanded) {
+			continue
+		}
+		expanded[b.node] = true
+		for _, node := range b.node.children() {
+			queue = append(queue, &branch{node: node, depth: b.depth + 1, parent: b})
+		}
+	}
+	return false
+}
+
+// branch is a Node queued by the breadth-first walks, which can't keep a single path set,
+// along with the branch leading to it from the starting Node.
+type branch struct {
+	node   *Node
+	depth  int
+	parent *branch
+}
+
+// cycles reports whether the Node is one of its own ancestors on the branch, the starting
+// Node root included. Only the branches of the nodes expanded already are walked,
+// the other nodes can't be ancestors, so the check is constant time for trees.
+func (b *branch) cycles(root *Node, expanded map[*Node]bool) bool {
+	if !expanded[b.node] {
+		return false
+	}
+	if b.node == root {
+		return true
+	}
+	for a := b.parent; a != nil; a = a.parent {
+		if a.node == b.node {
+			return true
+		}
+	}
+	return false
+}
+
+func bottomUp(n *Node, visit func(item *Node, depth int) WalkAction) bool {
+	levels := n.Levels()
+	for depth := len(levels) - 1; depth > 0; depth-- {
+		for _, node := range levels[depth] {
+			if visit(node, depth) == WalkStop {
+				return true
+			}
+		}
+	}
+	return false
+}
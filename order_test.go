@@ -0,0 +1,178 @@
+package treeprint
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalkOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	walk := func(order TraversalOrder, fn WalkFunc) []Value {
+		var visited []Value
+		traverseTree().WalkOrder(order, func(item *Node) WalkAction {
+			visited = append(visited, item.Value)
+			return fn(item)
+		})
+		return visited
+	}
+	cont := func(*Node) WalkAction { return WalkContinue }
+	skipOne := func(item *Node) WalkAction {
+		if item.Value == "one" {
+			return WalkSkipChildren
+		}
+		return WalkContinue
+	}
+	stopTwo := func(item *Node) WalkAction {
+		if item.Value == "two" {
+			return WalkStop
+		}
+		return WalkContinue
+	}
+
+	assert.Equal([]Value{"one", "a", "b", "two", "c", "three"}, walk(PreOrder, cont))
+	assert.Equal([]Value{"one", "three"}, walk(PreOrder, skipOne))
+	assert.Equal([]Value{"one", "a", "b", "two"}, walk(PreOrder, stopTwo))
+
+	assert.Equal([]Value{"a", "b", "c", "two", "one", "three"}, walk(PostOrder, cont))
+	assert.Equal([]Value{"a", "b", "c", "two"}, walk(PostOrder, stopTwo))
+
+	assert.Equal([]Value{"one", "three", "a", "b", "two", "c"}, walk(BreadthFirst, cont))
+	assert.Equal([]Value{"one", "three"}, walk(BreadthFirst, skipOne))
+	assert.Equal([]Value{"one", "three", "a", "b", "two"}, walk(BreadthFirst, stopTwo))
+
+	assert.Equal([]Value{"c", "a", "b", "two", "one", "three"}, walk(BottomUp, cont))
+	assert.Equal([]Value{"c", "a", "b", "two"}, walk(BottomUp, stopTwo))
+
+	// children in reverse order
+	reversed := TraversalOrderFunc(func(n *Node, visit func(*Node, int) WalkAction) bool {
+		for i := len(n.Nodes) - 1; i >= 0; i-- {
+			if visit(n.Nodes[i], 1) == WalkStop {
+				return true
+			}
+		}
+		return false
+	})
+	assert.Equal([]Value{"three", "one"}, walk(reversed, cont))
+}
+
+func TestWalkContext(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	for i := 0; i < 3*contextCheckInterval; i++ {
+		tree.AddNode(i)
+	}
+
+	var count int
+	assert.NoError(tree.VisitAllContext(context.Background(), func(item *Node) {
+		count++
+	}))
+	assert.Equal(3*contextCheckInterval, count)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	count = 0
+	err := tree.WalkContext(ctx, func(item *Node) WalkAction {
+		count++
+		if count == contextCheckInterval {
+			cancel()
+		}
+		return WalkContinue
+	})
+	assert.ErrorIs(err, context.Canceled)
+	assert.Equal(contextCheckInterval, count)
+
+	count = 0
+	err = tree.WalkOrderContext(ctx, BreadthFirst, func(item *Node) WalkAction {
+		count++
+		return WalkContinue
+	})
+	assert.ErrorIs(err, context.Canceled)
+	assert.Zero(count)
+}
+
+// cyclicTree returns a tree where a is a child of b, its own child, and the root a child of a.
+func cyclicTree() *Node {
+	tree := New().(*Node)
+	a := tree.AddBranch("a").(*Node)
+	b := a.AddBranch("b").(*Node)
+	b.AddNode("c")
+	// bypass AddTree, which refuses to create cycles
+	b.Nodes = append(b.Nodes, a)
+	a.Nodes = append(a.Nodes, tree)
+	return tree
+}
+
+func TestTraversalsCycle(t *testing.T) {
+	assert := assert.New(t)
+
+	newTree := cyclicTree
+
+	var visited []Value
+	newTree().VisitPostOrder(func(item *Node) {
+		visited = append(visited, item.Value)
+	})
+	assert.Equal([]Value{"c", "a", "b", ".", "a"}, visited)
+
+	visited = nil
+	newTree().VisitBFS(func(item *Node, depth int) {
+		visited = append(visited, item.Value)
+	})
+	assert.Equal([]Value{"a", "b", ".", "c", "a"}, visited)
+
+	assert.Len(newTree().Levels(), 4)
+	visited = nil
+	newTree().VisitBottomUp(func(item *Node, depth int) {
+		visited = append(visited, item.Value)
+	})
+	assert.Equal([]Value{"c", "a", "b", ".", "a"}, visited)
+
+	tree := newTree()
+	assert.NotEmpty(tree.Hash())
+	assert.Equal(tree.Hash(), newTree().Hash())
+
+	data, err := newTree().MarshalJSON()
+	assert.NoError(err)
+	assert.Equal(`{"value":".","children":[{"value":"a","children":[{"value":"b","children":[{"value":"c"},{"value":"a"}]},{"value":"."}]}]}`, string(data))
+
+	assert.Nil(newTree().FindByValue("missing"))
+	assert.NotNil(newTree().FindByValue("c"))
+
+	tree = newTree()
+	tree.Prune(func(item *Node) bool { return item.Value == "c" })
+	assert.Nil(tree.FindByValue("c"))
+
+	var mu sync.Mutex
+	visited = nil
+	newTree().VisitParallel(func(item *Node) {
+		mu.Lock()
+		visited = append(visited, item.Value)
+		mu.Unlock()
+	}, 4)
+	assert.ElementsMatch([]Value{"a", "b", "c", "a", "."}, visited)
+
+	tree = newTree()
+	tree.SortChildren(func(a, b *Node) bool { return valueString(a) < valueString(b) })
+	assert.Equal(".", tree.Nodes[0].Nodes[0].Value)
+
+	rendered := newTree().String()
+	var buf bytes.Buffer
+	_, err = NewIncrementalPrinter().Render(&buf, newTree())
+	assert.NoError(err)
+	assert.Equal(rendered, buf.String())
+
+	assert.Empty(Diff(newTree(), newTree()))
+	assert.Contains(Diff(newTree(), New()), "- └── a\n")
+
+	assert.Equal(".\n└── a\n    ├── b\n    │   ├── c\n    │   └── a\n    └── .\n", copyTree(newTree()).String())
+	assert.Equal(".\n└── a\n    ├── b\n    └── .\n", copyTreeDepth(newTree(), 2).String())
+	w := httptest.NewRecorder()
+	Handler(newTree()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?filter=c", nil))
+	assert.Contains(w.Body.String(), "└── c")
+}
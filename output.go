@@ -0,0 +1,69 @@
+package treeprint
+
+import (
+	"bytes"
+	"fmt"
+
+	"golang.org/x/text/transform"
+)
+
+// WithLineEnding ends the output lines with eol instead of "\n", such as "\r\n"
+// for the reports read on Windows. The byte limit counts the bytes of eol.
+func WithLineEnding(eol string) Option {
+	return func(p *PrinterOptions) {
+		p.lineEnding = eol
+	}
+}
+
+// WithTransformer passes the output through t before it is written, for example
+// to re-encode it with golang.org/x/text/encoding/charmap for legacy systems.
+// The output is transformed in pieces made of whole lines, t being reset before
+// each of them. A transformation error stops the rendering and is returned.
+func WithTransformer(t transform.Transformer) Option {
+	return func(p *PrinterOptions) {
+		p.transformer = t
+	}
+}
+
+// lineEnd returns the line ending of the output.
+func (p PrinterOptions) lineEnd() string {
+	if p.lineEnding == "" {
+		return "\n"
+	}
+	return p.lineEnding
+}
+
+// raw returns the options without the line ending and the transformer, for the printers
+// rendering into buffers that are written through a printer with the options afterwards,
+// so that the output is encoded once.
+func (p PrinterOptions) raw() PrinterOptions {
+	p.lineEnding, p.transformer = "", nil
+	return p
+}
+
+// encode returns b with the line ending and the transformer applied.
+func (p *printer) encode(b []byte) ([]byte, error) {
+	if p.pf.lineEnding != "" && p.pf.lineEnding != "\n" && bytes.IndexByte(b, '\n') >= 0 {
+		p.eol = p.eol[:0]
+		for {
+			i := bytes.IndexByte(b, '\n')
+			if i < 0 {
+				break
+			}
+			p.eol = append(p.eol, b[:i]...)
+			p.eol = append(p.eol, p.pf.lineEnding...)
+			b = b[i+1:]
+		}
+		b = append(p.eol, b...)
+		p.eol = b
+	}
+	if p.pf.transformer != nil {
+		var err error
+		p.encoded, _, err = transform.Append(p.pf.transformer, p.encoded[:0], b)
+		if err != nil {
+			return nil, fmt.Errorf("treeprint: transform output: %w", err)
+		}
+		b = p.encoded
+	}
+	return b, nil
+}
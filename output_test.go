@@ -0,0 +1,58 @@
+package treeprint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestWithLineEnding(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("one").AddNode("a\nb")
+	tree.AddNode("two")
+
+	f := NewPrinter(WithLineEnding("\r\n"))
+	assert.Equal(".\r\n├── one\r\n│   └── a\r\n│       b\r\n└── two\r\n", string(tree.Bytes(f)))
+	assert.Equal([]string{"├── one", "│   └── a"}, tree.RenderLines(f, 1, 3))
+	assert.Equal(tree.Measure(NewPrinter()), tree.Measure(f))
+	assert.Equal(tree.String(), string(tree.Bytes(NewPrinter(WithLineEnding("\n")))))
+
+	var buf bytes.Buffer
+	_, err := tree.PrintToParallel(&buf, f, 2)
+	assert.NoError(err)
+	assert.Equal(string(tree.Bytes(f)), buf.String())
+	buf.Reset()
+	_, err = NewIncrementalPrinter(WithLineEnding("\r\n")).Render(&buf, tree.(*Node))
+	assert.NoError(err)
+	assert.Equal(string(tree.Bytes(f)), buf.String())
+}
+
+func TestWithTransformer(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewWithRoot("café")
+	tree.AddNode("naïve")
+	f := NewPrinter(WithEdgeStyle(ASCIIEdgeStyle), WithLineEnding("\r\n"), WithTransformer(charmap.Windows1252.NewEncoder()))
+	assert.Equal("caf\xe9\r\n`-- na\xefve\r\n", string(tree.Bytes(f)))
+	var parallel bytes.Buffer
+	_, err := tree.PrintToParallel(&parallel, f, 2)
+	assert.NoError(err)
+	assert.Equal(string(tree.Bytes(f)), parallel.String())
+	options := []Option{WithEdgeStyle(ASCIIEdgeStyle), WithLineEnding("\r\n"), WithTransformer(charmap.Windows1252.NewEncoder())}
+	var incremental bytes.Buffer
+	_, err = NewIncrementalPrinter(options...).Render(&incremental, tree.(*Node))
+	assert.NoError(err)
+	assert.Equal(string(tree.Bytes(f)), incremental.String())
+
+	tree.AddNode("日本")
+	var buf bytes.Buffer
+	_, err = tree.PrintTo(&buf, f)
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "treeprint: transform output")
+	}
+	assert.Equal("caf\xe9\r\n|-- na\xefve\r\n", buf.String())
+}
@@ -0,0 +1,87 @@
+package treeprint
+
+import (
+	"bytes"
+	"io"
+)
+
+// PrintToParallel is like PrintTo, but renders the subtrees of the top-level children
+// concurrently, each into a separate buffer, using up to workers goroutines.
+// The buffers are written into w in the tree order, so the output is the same as PrintTo's.
+// The print funcs and lazy children funcs must be safe for concurrent use.
+func (n *Node) PrintToParallel(w io.Writer, f PrinterOptions, workers int) (int64, error) {
+	if n == nil {
+		return 0, nil
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	if f.limited() {
+		// the limits span all the subtrees
+		return n.PrintTo(w, f)
+	}
+	p := newPrinter(w, f)
+	defer p.release()
+	p.openFence()
+	p.writeAncestors(n)
+	n.renderHeader(p)
+
+	nodes := f.children(n)
+	bufs := make([]*bytes.Buffer, len(nodes))
+	done := make([]chan struct{}, len(nodes))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	jobs := make(chan int)
+	abort := make(chan struct{})
+	go func() {
+		defer close(jobs)
+		for i := range nodes {
+			select {
+			case jobs <- i:
+			case <-abort:
+				return
+			}
+		}
+	}()
+	for i := 0; i < workers; i++ {
+		go func() {
+			for i := range jobs {
+				buf := getBuffer()
+				sp := newPrinter(buf, f.raw())
+				renderSubtree(sp, n, nodes[i], i == len(nodes)-1)
+				sp.release()
+				bufs[i] = buf
+				close(done[i])
+			}
+		}()
+	}
+	for i := range nodes {
+		<-done[i]
+		p.Write(bufs[i].Bytes())
+		putBuffer(bufs[i])
+		if p.stop {
+			close(abort)
+			break
+		}
+	}
+	p.closeFence()
+	return p.n, p.err
+}
+
+// renderSubtree renders a top-level child of root and its subtree, as printNodes would.
+func renderSubtree(p *printer, root, node *Node, last bool) {
+	p.setEnded(0, last)
+	if node == root {
+		printCycle(p, 0, last, node)
+		return
+	}
+	printValues(p, 0, last, node)
+	if !p.belowMaxDepth(node, 0) || !p.expanded(node) {
+		return
+	}
+	if nodes := p.pf.children(node); len(nodes) > 0 {
+		p.path = map[*Node]bool{root: true}
+		printNodes(p, 1, node, nodes)
+	}
+}
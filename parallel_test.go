@@ -0,0 +1,42 @@
+package treeprint
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintToParallel(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := benchmarkTree(5, 3)
+	tree.AddNode("multi\nline")
+	for _, workers := range []int{0, 1, 3, 16} {
+		buf := new(bytes.Buffer)
+		n, err := tree.PrintToParallel(buf, NewPrinter(), workers)
+		assert.NoError(err)
+		assert.Equal(tree.String(), buf.String())
+		assert.Equal(int64(buf.Len()), n)
+	}
+
+	limited := NewPrinter(WithMaxDepth(2))
+	buf := new(bytes.Buffer)
+	_, err := tree.PrintToParallel(buf, limited, 4)
+	assert.NoError(err)
+	assert.Equal(string(tree.Bytes(limited)), buf.String())
+
+	n, err := tree.PrintToParallel(&limitWriter{limit: 100}, NewPrinter(), 4)
+	assert.Error(err)
+	assert.Equal(int64(100), n)
+}
+
+func BenchmarkRenderWideParallel(b *testing.B) {
+	tree := benchmarkTree(1000, 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = tree.PrintToParallel(io.Discard, NewPrinter(), 8)
+	}
+}
@@ -0,0 +1,252 @@
+package treeprint
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrSyntax is reported when the parsed text is not a rendered tree.
+var ErrSyntax = errors.New("treeprint: syntax error")
+
+// ParseMode tells the parsers how to handle malformed input.
+type ParseMode int
+
+const (
+	// StrictParse fails on the first malformed line or node, it is the default.
+	StrictParse ParseMode = iota
+	// LenientParse recovers from malformed lines and nodes, the way it's described
+	// by every parser, and marks the nodes built from them with a Suspect meta.
+	LenientParse
+)
+
+// Suspect is the meta given by LenientParse to the nodes built from malformed input.
+type Suspect struct {
+	// Line is the line of the input the problem was found on, 0 when unknown.
+	Line int
+	// Reason describes the problem.
+	Reason string
+	// Meta is the meta the Node would have had otherwise.
+	Meta MetaValue
+}
+
+func (s Suspect) String() string {
+	if s.Line > 0 {
+		return fmt.Sprintf("suspect: line %d: %s", s.Line, s.Reason)
+	}
+	return "suspect: " + s.Reason
+}
+
+// Parse reads a tree rendered as String does, and builds it back. The edges and the
+// indentation are the ones of the given options, given to the parsed tree as New does,
+// the default ones without options. Metas and values are parsed as strings, the metas
+// being recognized by the "[meta]  " form of the default meta printer.
+func Parse(r io.Reader, options ...Option) (Tree, error) {
+	return ParseWith(r, StrictParse, options...)
+}
+
+// ParseWith is like Parse, handling malformed input according to mode.
+// With LenientParse a Node indented deeper than possible is added to the last parsed one,
+// and a line that is neither a Node nor correctly indented as part of a multiline value
+// is still added to the value of the last parsed Node.
+func ParseWith(r io.Reader, mode ParseMode, options ...Option) (Tree, error) {
+	t, err := parse(r, mode, NewPrinter(options...))
+	if err != nil {
+		return nil, err
+	}
+	if len(options) > 0 {
+		pf := NewPrinter(options...)
+		t.options = &pf
+	}
+	return t, nil
+}
+
+// parse builds the tree drawn with the edges and the indentation of pf.
+func parse(r io.Reader, mode ParseMode, pf PrinterOptions) (*Node, error) {
+	p := parser{edges: pf.edgeStyle(), mode: mode}
+	p.link, p.blank = pf.segments()
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		if err := p.parseLine(s.Text()); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("treeprint: parse: %w", err)
+	}
+	if p.root == nil {
+		return nil, fmt.Errorf("%w: empty input", ErrSyntax)
+	}
+	return p.root, nil
+}
+
+// ParseString is like Parse, reading from s.
+func ParseString(s string, options ...Option) (Tree, error) {
+	return Parse(strings.NewReader(s), options...)
+}
+
+type parser struct {
+	edges EdgeStyle
+	link  string
+	blank string
+	mode  ParseMode
+	root  *Node
+	// path holds the last parsed Node of every level, the root first.
+	path   []*Node
+	lineNo int
+}
+
+func (p *parser) parseLine(line string) error {
+	p.lineNo++
+	if p.root == nil {
+		p.root = &Node{}
+		p.root.Meta, p.root.Value = parseMetaValue(line)
+		p.path = append(p.path, p.root)
+		return nil
+	}
+	rest, level := line, 0
+	for {
+		if s := strings.TrimPrefix(rest, p.link); s != rest {
+			rest = s
+		} else if s := strings.TrimPrefix(rest, p.blank); s != rest {
+			rest = s
+		} else {
+			break
+		}
+		level++
+	}
+	for _, edge := range []EdgeType{p.edges.Mid, p.edges.End} {
+		if s := strings.TrimPrefix(rest, string(edge)+" "); s != rest {
+			return p.addNode(level, s)
+		}
+	}
+	return p.continueValue(line)
+}
+
+func (p *parser) addNode(level int, s string) error {
+	suspect := level >= len(p.path)
+	if suspect {
+		if p.mode == StrictParse {
+			return fmt.Errorf("%w: line %d: unexpected indentation", ErrSyntax, p.lineNo)
+		}
+		level = len(p.path) - 1
+	}
+	parent := p.path[level]
+	node := parent.newChild(parseMetaValue(s))
+	if suspect {
+		node.Meta = Suspect{Line: p.lineNo, Reason: "unexpected indentation", Meta: node.Meta}
+	}
+	parent.Nodes = append(parent.Nodes, node)
+	p.path = append(p.path[:level+1], node)
+	return nil
+}
+
+// continueValue appends a line of a multiline value to the last parsed Node,
+// the line is indented one level deeper than the Node itself.
+func (p *parser) continueValue(line string) error {
+	node := p.path[len(p.path)-1]
+	// the root has no prefix, a Node of level i has i+1 segments
+	for i := 1; i < len(p.path); i++ {
+		if s := strings.TrimPrefix(line, p.link); s != line {
+			line = s
+		} else if s := strings.TrimPrefix(line, p.blank); s != line {
+			line = s
+		} else if p.mode == StrictParse {
+			return fmt.Errorf("%w: line %d: unexpected indentation", ErrSyntax, p.lineNo)
+		} else {
+			line = strings.TrimLeft(line, " "+string(p.edges.Link))
+			if _, ok := node.Meta.(Suspect); !ok {
+				node.Meta = Suspect{Line: p.lineNo, Reason: "unexpected indentation", Meta: node.Meta}
+			}
+			break
+		}
+	}
+	node.Value = fmt.Sprintf("%v\n%s", node.Value, line)
+	return nil
+}
+
+// parseMetaValue splits the "[meta]  value" form of the default printer.
+func parseMetaValue(s string) (MetaValue, Value) {
+	if strings.HasPrefix(s, "[") {
+		if i := strings.Index(s, "]  "); i > 0 {
+			return s[1:i], s[i+3:]
+		}
+	}
+	return nil, s
+}
+
+// MarshalText renders the tree or subtree with the printer options of the tree, see Options.
+func (n *Node) MarshalText() ([]byte, error) {
+	return n.Bytes(n.Options()), nil
+}
+
+// UnmarshalText replaces the Node content with the tree parsed from text, see Parse.
+// The text is parsed with the edges and the indentation of the tree, as MarshalText renders it.
+func (n *Node) UnmarshalText(text []byte) error {
+	parsed, err := parse(strings.NewReader(string(text)), StrictParse, n.Options())
+	if err != nil {
+		return err
+	}
+	n.Meta, n.Value, n.Nodes = parsed.Meta, parsed.Value, parsed.Nodes
+	n.lazy = nil
+	for _, node := range n.Nodes {
+		node.Root = n
+	}
+	n.MarkDirty()
+	return nil
+}
+
+// ParseIndented reads an outline where every line is a Node, indented deeper than
+// its parent with spaces or tabs, and builds its tree under a "." root.
+// Blank lines are skipped. Lines must be indented like one of their ancestors
+// or deeper than the previous line.
+func ParseIndented(r io.Reader) (Tree, error) {
+	return ParseIndentedWith(r, StrictParse)
+}
+
+// ParseIndentedWith is like ParseIndented, handling malformed input according to mode.
+// With LenientParse a line indented unlike its ancestors is added as a child
+// of the closest of them indented less.
+func ParseIndentedWith(r io.Reader, mode ParseMode) (Tree, error) {
+	root := &Node{Value: "."}
+	type level struct {
+		indent int
+		node   *Node
+	}
+	// path holds the indentation of the last parsed Node of every level
+	path := []level{{indent: -1, node: root}}
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for lineNo := 1; s.Scan(); lineNo++ {
+		line := s.Text()
+		text := strings.TrimLeft(line, " \t")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		indent := len(line) - len(text)
+		// the line is a sibling of the last popped Node, so it's indented the same
+		sibling := indent
+		for indent <= path[len(path)-1].indent {
+			sibling = path[len(path)-1].indent
+			path = path[:len(path)-1]
+		}
+		var meta MetaValue
+		if indent != sibling {
+			if mode == StrictParse {
+				return nil, fmt.Errorf("%w: line %d: unexpected indentation", ErrSyntax, lineNo)
+			}
+			meta = Suspect{Line: lineNo, Reason: "unexpected indentation"}
+		}
+		parent := path[len(path)-1].node
+		node := parent.newChild(meta, strings.TrimRight(text, " \t\r"))
+		parent.Nodes = append(parent.Nodes, node)
+		path = append(path, level{indent: indent, node: node})
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("treeprint: parse: %w", err)
+	}
+	return root, nil
+}
@@ -0,0 +1,138 @@
+package treeprint
+
+import (
+	"encoding"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewWithRoot("root\nvalue")
+	one := tree.AddMetaBranch("m", "one")
+	one.AddNode("a\nmulti\nline")
+	one.AddBranch("b").AddNode("c\nd")
+	tree.AddNode("two")
+	tree.AddBranch("three").AddMetaNode("x", "e")
+
+	parsed, err := ParseString(tree.String())
+	if assert.NoError(err) {
+		assert.Equal(tree.String(), parsed.String())
+		assert.NoError(parsed.Validate())
+		assert.Equal("m", parsed.(*Node).Nodes[0].Meta)
+	}
+
+	_, err = ParseString("")
+	assert.True(errors.Is(err, ErrSyntax))
+	_, err = ParseString(".\n│   ├── a")
+	assert.True(errors.Is(err, ErrSyntax))
+	_, err = ParseString(".\n├── a\n│   └── b\nc")
+	assert.EqualError(err, "treeprint: syntax error: line 4: unexpected indentation")
+
+	ascii := NewWithRoot("root\nvalue", WithEdgeStyle(ASCIIEdgeStyle), WithIndentSize(1))
+	ascii.AddBranch("one").AddNode("a\nb")
+	ascii.AddNode("two")
+	parsed, err = ParseString(ascii.String(), WithEdgeStyle(ASCIIEdgeStyle), WithIndentSize(1))
+	if assert.NoError(err) {
+		assert.Equal(ascii.String(), parsed.String())
+		assert.Len(parsed.(*Node).Nodes, 2)
+	}
+}
+
+func TestTextMarshaler(t *testing.T) {
+	assert := assert.New(t)
+
+	var _ encoding.TextMarshaler = New()
+	var _ encoding.TextUnmarshaler = New()
+
+	tree := New()
+	tree.AddBranch("one").AddNode("a")
+	text, err := tree.MarshalText()
+	assert.NoError(err)
+	assert.Equal(tree.String(), string(text))
+
+	other := NewWithRoot("old")
+	other.AddNode("dropped")
+	assert.NoError(other.UnmarshalText(text))
+	assert.Equal(tree.String(), other.String())
+	assert.NoError(other.Validate())
+
+	ascii := New(WithEdgeStyle(ASCIIEdgeStyle))
+	ascii.AddBranch("one").AddNode("a")
+	ascii.AddNode("two")
+	text, err = ascii.MarshalText()
+	assert.NoError(err)
+	parsed := New(WithEdgeStyle(ASCIIEdgeStyle))
+	assert.NoError(parsed.UnmarshalText(text), "the text is parsed with the edges of the tree")
+	assert.Len(parsed.(*Node).Nodes, 2)
+	assert.Equal(string(text), parsed.String())
+}
+
+func TestParseIndented(t *testing.T) {
+	assert := assert.New(t)
+
+	tree, err := ParseIndented(strings.NewReader(`one
+    a
+
+    b
+      c
+two
+	d
+`))
+	if assert.NoError(err) {
+		assert.Equal(`.
+├── one
+│   ├── a
+│   └── b
+│       └── c
+└── two
+    └── d
+`, tree.String())
+	}
+
+	_, err = ParseIndented(strings.NewReader("one\n    a\n  b\n"))
+	assert.EqualError(err, "treeprint: syntax error: line 3: unexpected indentation")
+}
+
+func TestParseModes(t *testing.T) {
+	assert := assert.New(t)
+
+	text := `.
+├── a
+  b
+│       └── c
+└── [m]  d
+ e
+`
+	_, err := ParseString(text)
+	assert.ErrorIs(err, ErrSyntax)
+	assert.EqualError(err, "treeprint: syntax error: line 3: unexpected indentation")
+
+	tree, err := ParseWith(strings.NewReader(text), LenientParse)
+	assert.NoError(err)
+	assert.Equal(`.
+├── [suspect: line 3: unexpected indentation]  a
+│   b
+│   └── [suspect: line 4: unexpected indentation]  c
+└── [suspect: line 6: unexpected indentation]  d
+    e
+`, tree.String())
+	d := tree.(*Node).Nodes[1]
+	assert.Equal("m", d.Meta.(Suspect).Meta)
+
+	indented := "a\n    b\n  c\nd\n"
+	_, err = ParseIndented(strings.NewReader(indented))
+	assert.EqualError(err, "treeprint: syntax error: line 3: unexpected indentation")
+	tree, err = ParseIndentedWith(strings.NewReader(indented), LenientParse)
+	assert.NoError(err)
+	assert.Equal(`.
+├── a
+│   ├── b
+│   └── [suspect: line 3: unexpected indentation]  c
+└── d
+`, tree.String())
+}
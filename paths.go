@@ -0,0 +1,56 @@
+package treeprint
+
+import (
+	"bufio"
+	"io"
+)
+
+// Paths returns the paths from the Node down to each of its descendants, or to each
+// of its leaves only, in depth-first pre-order. The values along a path are formatted
+// with %v and joined by sep, the value of the Node itself is left out, so that FromPaths
+// builds the tree back. It's meant for shell completion data and fuzzy-finder input.
+func (n *Node) Paths(sep string, leavesOnly bool) []string {
+	var paths []string
+	n.walkPaths(sep, leavesOnly, func(path string) bool {
+		paths = append(paths, path)
+		return true
+	})
+	return paths
+}
+
+// WritePaths writes the paths returned by Paths into w, one per line.
+// It returns the first write error encountered.
+func (n *Node) WritePaths(w io.Writer, sep string, leavesOnly bool) error {
+	bw := bufio.NewWriter(w)
+	var err error
+	n.walkPaths(sep, leavesOnly, func(path string) bool {
+		if _, err = bw.WriteString(path); err == nil {
+			err = bw.WriteByte('\n')
+		}
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// walkPaths calls fn with the path of every descendant until it returns false.
+func (n *Node) walkPaths(sep string, leavesOnly bool, fn func(path string) bool) {
+	// prefixes holds the paths of the ancestors of the visited Node, by depth
+	prefixes := []string{""}
+	walkNodes(n, func(item *Node, depth int, _ *Node) WalkAction {
+		path := sprint(item.Value)
+		if depth > 1 {
+			path = prefixes[depth-1] + sep + path
+		}
+		prefixes = append(prefixes[:depth], path)
+		if leavesOnly && len(item.children()) > 0 {
+			return WalkContinue
+		}
+		if !fn(path) {
+			return WalkStop
+		}
+		return WalkContinue
+	})
+}
@@ -0,0 +1,26 @@
+package treeprint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaths(t *testing.T) {
+	assert := assert.New(t)
+
+	paths := []string{"cmd/run", "cmd/build/fast", "docs", "cmd/build/slow"}
+	tree := FromPaths(paths, "/")
+	assert.Equal([]string{"cmd/run", "cmd/build/fast", "cmd/build/slow", "docs"}, tree.Paths("/", true))
+	assert.Equal([]string{"cmd", "cmd/run", "cmd/build", "cmd/build/fast", "cmd/build/slow", "docs"}, tree.Paths("/", false))
+	assert.Equal(tree.String(), FromPaths(tree.Paths("/", true), "/").String())
+	assert.Equal([]string{"run", "build fast", "build slow"}, tree.FindByValue("cmd").Paths(" ", true))
+
+	var b strings.Builder
+	assert.NoError(tree.WritePaths(&b, ".", true))
+	assert.Equal("cmd.run\ncmd.build.fast\ncmd.build.slow\ndocs\n", b.String())
+
+	assert.Error(tree.WritePaths(&limitWriter{limit: 4}, "/", false))
+	assert.Empty(New().Paths("/", false))
+}
@@ -0,0 +1,80 @@
+package treeprint
+
+// PlaceholderMarker is the start of the rendered value of a Placeholder.
+const PlaceholderMarker = "… "
+
+// Placeholder is the value of a Node standing for an unloaded region of a huge tree,
+// such as the next page of children, that interactive and paginated consumers load
+// on demand with Node.Expand. A ChildrenFunc may return a placeholder after
+// the first page of children, so that the rest stays unloaded until expanded.
+type Placeholder struct {
+	// Text follows PlaceholderMarker in the rendered value, as "… 120 more, press enter to load".
+	Text string
+	// Load produces the nodes replacing the placeholder, it may return another placeholder
+	// at the end for the next page.
+	Load ChildrenFunc
+}
+
+// String returns the rendered value of the placeholder.
+func (p *Placeholder) String() string {
+	return PlaceholderMarker + p.Text
+}
+
+// AddPlaceholder adds a placeholder Node whose value is a Placeholder with the given text
+// and load func, and returns it.
+func (n *Node) AddPlaceholder(text string, load ChildrenFunc) Tree {
+	if n == nil {
+		return n
+	}
+	child := n.newChild(nil, &Placeholder{Text: text, Load: load})
+	n.Nodes = append(n.Nodes, child)
+	n.MarkDirty()
+	n.emitAdd(child)
+	return child
+}
+
+// IsPlaceholder reports whether the value of the Node is a Placeholder.
+func (n *Node) IsPlaceholder() bool {
+	if n == nil {
+		return false
+	}
+	_, ok := n.Value.(*Placeholder)
+	return ok
+}
+
+// Expand replaces the placeholder Node among the children of its parent with the nodes
+// produced by its Load func, in place, and returns them. It does nothing and returns nil
+// for a Node that is not a placeholder, has no parent or no Load func.
+func (n *Node) Expand() []*Node {
+	if n == nil {
+		return nil
+	}
+	ph, ok := n.Value.(*Placeholder)
+	if !ok || ph.Load == nil || n.Root == nil {
+		return nil
+	}
+	parent := n.Root
+	i := parent.indexOf(n)
+	if i < 0 {
+		return nil
+	}
+	nodes := ph.Load()
+	n.detach()
+	tail := append([]*Node(nil), parent.Nodes[i:]...)
+	parent.Nodes = append(append(parent.Nodes[:i], nodes...), tail...)
+	for j := i; j < len(parent.Nodes); j++ {
+		parent.adopt(parent.Nodes[j])
+		parent.Nodes[j].Root = parent
+		parent.Nodes[j].index = j
+	}
+	parent.MarkDirty()
+	for _, node := range nodes {
+		parent.emitAdd(node)
+	}
+	return nodes
+}
+
+// Placeholders matches the placeholder nodes, as for rendering them dimmed with WithStyleRules.
+func Placeholders(n *Node, _ int) bool {
+	return n.IsPlaceholder()
+}
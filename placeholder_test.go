@@ -0,0 +1,67 @@
+package treeprint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceholder(t *testing.T) {
+	assert := assert.New(t)
+
+	// pages of two items, each ending with a placeholder for the next one
+	var page func(from int) ChildrenFunc
+	page = func(from int) ChildrenFunc {
+		return func() []*Node {
+			nodes := []*Node{{Value: fmt.Sprint("item ", from)}, {Value: fmt.Sprint("item ", from+1)}}
+			if from+2 < 6 {
+				nodes = append(nodes, &Node{Value: &Placeholder{Text: fmt.Sprintf("%d more", 6-from-2), Load: page(from + 2)}})
+			}
+			return nodes
+		}
+	}
+
+	tree := New()
+	list := tree.AddBranch("list")
+	list.SetChildrenFunc(page(0))
+	tree.AddNode("after")
+
+	var added []Value
+	cancel := tree.Observe(Observer{OnAdd: func(_, child *Node) { added = append(added, child.Value) }})
+	defer cancel()
+
+	assert.Equal(`.
+├── list
+│   ├── item 0
+│   ├── item 1
+│   └── … 4 more
+└── after
+`, tree.String())
+	more := list.FindLastNode()
+	assert.True(more.IsPlaceholder())
+	assert.False(list.IsPlaceholder())
+	assert.Nil(list.Expand())
+
+	nodes := more.Expand()
+	assert.Len(nodes, 3)
+	assert.Equal([]Value{"item 2", "item 3", nodes[2].Value}, added)
+	assert.Nil(more.(*Node).Root)
+	nodes[2].Expand()
+	assert.Equal(`.
+├── list
+│   ├── item 0
+│   ├── item 1
+│   ├── item 2
+│   ├── item 3
+│   ├── item 4
+│   └── item 5
+└── after
+`, tree.String())
+	assert.NoError(tree.Validate())
+
+	ph := tree.AddPlaceholder("load", nil)
+	assert.Nil(ph.Expand())
+	f := NewPrinter(WithStyleRules(StyleRule{Match: Placeholders, Style: Dim}))
+	assert.Contains(tree.Print(f), "└── \x1b[2m… load\x1b[0m")
+}
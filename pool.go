@@ -0,0 +1,76 @@
+package treeprint
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledSize is the capacity above which buffers are not returned to the pools,
+// so that a single huge render doesn't pin its memory for good.
+const maxPooledSize = 64 << 10
+
+var (
+	printerPool = sync.Pool{
+		New: func() interface{} {
+			return new(printer)
+		},
+	}
+	bufferPool = sync.Pool{
+		New: func() interface{} {
+			return new(bytes.Buffer)
+		},
+	}
+)
+
+// release returns the printer and its scratch buffers to the pool.
+func (p *printer) release() {
+	if cap(p.line) > maxPooledSize || p.value.Cap() > maxPooledSize || p.hooked.Cap() > maxPooledSize {
+		return
+	}
+	p.w = nil
+	p.pf = PrinterOptions{}
+	p.n = 0
+	p.err = nil
+	p.line = p.line[:0]
+	p.ended = p.ended[:0]
+	p.value.Reset()
+	p.hooked.Reset()
+	p.windowed, p.from, p.to, p.lineNo, p.stop = false, 0, 0, 0, false
+	p.nodes, p.unlimited, p.markup, p.escapeHTML = 0, false, 0, false
+	p.ctx = nil
+	p.marked = p.marked[:0]
+	p.annotation = p.annotation[:0]
+	p.ruled = p.ruled[:0]
+	p.onLine, p.pending = nil, pendingLine{}
+	p.levelOffset = 0
+	p.truncated = p.truncated[:0]
+	p.eol, p.encoded = p.eol[:0], p.encoded[:0]
+	p.described = p.described[:0]
+	p.expand = nil
+	for node := range p.path {
+		delete(p.path, node)
+	}
+	printerPool.Put(p)
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledSize {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// appendWriter is an io.Writer appending to a caller supplied slice.
+type appendWriter struct {
+	b []byte
+}
+
+func (w *appendWriter) Write(p []byte) (int, error) {
+	w.b = append(w.b, p...)
+	return len(p), nil
+}
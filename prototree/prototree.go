@@ -0,0 +1,146 @@
+// Package prototree builds treeprint trees of protobuf descriptors:
+// services with their methods, and messages with their fields, oneofs,
+// nested messages and enums. The types are shown as metas.
+package prototree
+
+import (
+	"fmt"
+
+	"github.com/ououmania/treeprint"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FromFile builds the tree of a file, named after its path with its package as the meta.
+// Its services come first, then its messages and enums, in declaration order.
+func FromFile(fd protoreflect.FileDescriptor) treeprint.Tree {
+	tree := treeprint.NewWithRoot(fd.Path())
+	if fd.Package() != "" {
+		tree.SetMetaValue("package " + string(fd.Package()))
+	}
+	services := fd.Services()
+	for i := 0; i < services.Len(); i++ {
+		addService(tree, services.Get(i))
+	}
+	addMessages(tree, fd.Messages(), fd.Enums())
+	return tree
+}
+
+// FromService builds the tree of a service and its methods.
+func FromService(sd protoreflect.ServiceDescriptor) treeprint.Tree {
+	tree := treeprint.NewWithRoot(string(sd.FullName()))
+	tree.SetMetaValue("service")
+	addMethods(tree, sd)
+	return tree
+}
+
+// FromMessage builds the tree of a message, its fields and its nested types.
+func FromMessage(md protoreflect.MessageDescriptor) treeprint.Tree {
+	tree := treeprint.NewWithRoot(string(md.FullName()))
+	tree.SetMetaValue("message")
+	addFields(tree, md)
+	addMessages(tree, md.Messages(), md.Enums())
+	return tree
+}
+
+func addService(tree treeprint.Tree, sd protoreflect.ServiceDescriptor) {
+	addMethods(tree.AddMetaBranch("service", string(sd.Name())), sd)
+}
+
+func addMethods(tree treeprint.Tree, sd protoreflect.ServiceDescriptor) {
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		m := methods.Get(i)
+		in, out := string(m.Input().FullName()), string(m.Output().FullName())
+		if m.IsStreamingClient() {
+			in = "stream " + in
+		}
+		if m.IsStreamingServer() {
+			out = "stream " + out
+		}
+		tree.AddMetaNode("rpc", fmt.Sprintf("%s(%s) returns (%s)", m.Name(), in, out))
+	}
+}
+
+// addMessages adds the messages and the enums, and the nested ones of the messages.
+func addMessages(tree treeprint.Tree, messages protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors) {
+	type entry struct {
+		tree treeprint.Tree
+		md   protoreflect.MessageDescriptor
+	}
+	var stack []entry
+	add := func(tree treeprint.Tree, messages protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors) {
+		var nested []entry
+		for i := 0; messages != nil && i < messages.Len(); i++ {
+			md := messages.Get(i)
+			if md.IsMapEntry() {
+				// shown as the map field type
+				continue
+			}
+			nested = append(nested, entry{tree: tree.AddMetaBranch("message", string(md.Name())), md: md})
+		}
+		for i := 0; enums != nil && i < enums.Len(); i++ {
+			addEnum(tree, enums.Get(i))
+		}
+		for i := len(nested) - 1; i >= 0; i-- {
+			stack = append(stack, nested[i])
+		}
+	}
+	add(tree, messages, enums)
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		addFields(e.tree, e.md)
+		add(e.tree, e.md.Messages(), e.md.Enums())
+	}
+}
+
+func addEnum(tree treeprint.Tree, ed protoreflect.EnumDescriptor) {
+	branch := tree.AddMetaBranch("enum", string(ed.Name()))
+	values := ed.Values()
+	for i := 0; i < values.Len(); i++ {
+		v := values.Get(i)
+		branch.AddMetaNode(int32(v.Number()), string(v.Name()))
+	}
+}
+
+// addFields adds the fields of a message, the ones of a oneof being grouped in its branch.
+func addFields(tree treeprint.Tree, md protoreflect.MessageDescriptor) {
+	oneofs := make(map[protoreflect.FullName]treeprint.Tree)
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		parent := tree
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			if parent = oneofs[od.FullName()]; parent == nil {
+				parent = tree.AddMetaBranch("oneof", string(od.Name()))
+				oneofs[od.FullName()] = parent
+			}
+		}
+		parent.AddMetaNode(fieldType(fd), fmt.Sprintf("%s = %d", fd.Name(), fd.Number()))
+	}
+}
+
+// fieldType returns the type of a field as written in a .proto file.
+func fieldType(fd protoreflect.FieldDescriptor) string {
+	if fd.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", kindName(fd.MapKey()), kindName(fd.MapValue()))
+	}
+	name := kindName(fd)
+	switch {
+	case fd.IsList():
+		return "repeated " + name
+	case fd.HasOptionalKeyword():
+		return "optional " + name
+	}
+	return name
+}
+
+func kindName(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(fd.Message().FullName())
+	case protoreflect.EnumKind:
+		return string(fd.Enum().FullName())
+	}
+	return fd.Kind().String()
+}
@@ -0,0 +1,82 @@
+package prototree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestFromFile(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(`[package google.protobuf]  google/protobuf/struct.proto
+├── [message]  Struct
+│   └── [map<string, google.protobuf.Value>]  fields = 1
+├── [message]  Value
+│   └── [oneof]  kind
+│       ├── [google.protobuf.NullValue]  null_value = 1
+│       ├── [double]  number_value = 2
+│       ├── [string]  string_value = 3
+│       ├── [bool]  bool_value = 4
+│       ├── [google.protobuf.Struct]  struct_value = 5
+│       └── [google.protobuf.ListValue]  list_value = 6
+├── [message]  ListValue
+│   └── [repeated google.protobuf.Value]  values = 1
+└── [enum]  NullValue
+    └── [0]  NULL_VALUE
+`, FromFile(structpb.File_google_protobuf_struct_proto).String())
+}
+
+func TestFromService(t *testing.T) {
+	assert := assert.New(t)
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("echo.proto"),
+		Package: proto.String("echo"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Msg"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:           proto.String("text"),
+				Number:         proto.Int32(1),
+				Type:           descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Label:          descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Proto3Optional: proto.Bool(true),
+				OneofIndex:     proto.Int32(0),
+			}},
+			OneofDecl:  []*descriptorpb.OneofDescriptorProto{{Name: proto.String("_text")}},
+			NestedType: []*descriptorpb.DescriptorProto{{Name: proto.String("Inner")}},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Echo"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Say"),
+				InputType:  proto.String(".echo.Msg"),
+				OutputType: proto.String(".echo.Msg"),
+			}, {
+				Name:            proto.String("Chat"),
+				InputType:       proto.String(".echo.Msg"),
+				OutputType:      proto.String(".echo.Msg"),
+				ClientStreaming: proto.Bool(true),
+				ServerStreaming: proto.Bool(true),
+			}},
+		}},
+	}
+	fd, err := protodesc.NewFile(fdp, nil)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal(`[service]  echo.Echo
+├── [rpc]  Say(echo.Msg) returns (echo.Msg)
+└── [rpc]  Chat(stream echo.Msg) returns (stream echo.Msg)
+`, FromService(fd.Services().Get(0)).String())
+	assert.Equal(`[message]  echo.Msg
+├── [optional string]  text = 1
+└── [message]  Inner
+`, FromMessage(fd.Messages().Get(0)).String())
+	assert.Contains(FromFile(fd).String(), "├── [service]  Echo\n│   ├── [rpc]  Say(echo.Msg) returns (echo.Msg)\n")
+}
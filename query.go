@@ -0,0 +1,280 @@
+package treeprint
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrQuerySyntax is reported for a query that can't be compiled, see CompileQuery.
+var ErrQuerySyntax = errors.New("treeprint: invalid query")
+
+// Query selects nodes by their path, see CompileQuery.
+type Query struct {
+	expr  string
+	steps []queryStep
+}
+
+// queryStep selects among the children of the current nodes, or among
+// all their descendants and themselves for "**".
+type queryStep struct {
+	anyDepth bool
+	name     string
+	literal  bool
+	preds    []queryPred
+}
+
+type queryPred struct {
+	meta bool
+	op   string
+	text string
+	re   *regexp.Regexp
+}
+
+// CompileQuery compiles a path-based query such as `services/*[meta=prod]/*`.
+// It is made of steps separated by slashes, each one selecting among the children
+// of the nodes selected by the previous step, the first one among the children
+// of the Node the query runs on, as FindByPath does. When none of them matches,
+// the first step is matched against the Node itself, so that the query may start
+// with the root, as `root/services[meta=prod]/*` on a tree whose root is "root":
+//
+//	name          the children whose value formats as name, which may hold
+//	              the wildcards of path.Match, as "*" or "*.go"
+//	"a/b"         the children whose value is a/b, quoted as a Go string
+//	**            the nodes themselves and all their descendants
+//	name[pred]    the children also satisfying every predicate in brackets
+//
+// A predicate compares the meta value or the value, formatted with %v, to a text
+// that may be quoted: [meta=prod] and [value!=x] test equality, and [meta~^v1\.]
+// matches a regular expression.
+func CompileQuery(expr string) (*Query, error) {
+	q := &Query{expr: expr}
+	c := queryCompiler{expr: expr}
+	for {
+		step, err := c.step()
+		if err != nil {
+			return nil, err
+		}
+		q.steps = append(q.steps, step)
+		if c.pos == len(expr) {
+			return q, nil
+		}
+		// the step stopped at a slash
+		c.pos++
+	}
+}
+
+// String returns the expression the Query was compiled from.
+func (q *Query) String() string {
+	return q.expr
+}
+
+// Select returns the nodes below n selected by the query, each one once, in the order they are reached.
+func (q *Query) Select(n *Node) []Tree {
+	if n == nil {
+		return nil
+	}
+	current := []*Node{n}
+	for i, step := range q.steps {
+		seen := make(map[*Node]bool)
+		var next []*Node
+		add := func(node *Node) {
+			if !seen[node] {
+				seen[node] = true
+				next = append(next, node)
+			}
+		}
+		for _, node := range current {
+			if step.anyDepth {
+				add(node)
+				walkNodes(node, func(item *Node, _ int, _ *Node) WalkAction {
+					add(item)
+					return WalkContinue
+				})
+				continue
+			}
+			for _, child := range node.children() {
+				if step.match(child) {
+					add(child)
+				}
+			}
+		}
+		if i == 0 && len(next) == 0 && !step.anyDepth && step.match(n) {
+			// a leading root step
+			next = append(next, n)
+		}
+		current = next
+	}
+	selected := make([]Tree, len(current))
+	for i, node := range current {
+		selected[i] = node
+	}
+	return selected
+}
+
+// Query returns the nodes below n selected by the query expression, see CompileQuery.
+func (n *Node) Query(expr string) ([]Tree, error) {
+	q, err := CompileQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	return q.Select(n), nil
+}
+
+func (s queryStep) match(n *Node) bool {
+	value := sprint(n.Value)
+	if s.literal {
+		if value != s.name {
+			return false
+		}
+	} else if ok, _ := path.Match(s.name, strings.ReplaceAll(value, "/", "\x00")); !ok {
+		// the wildcards of path.Match stop at slashes, which the patterns
+		// can't hold, but the values can
+		return false
+	}
+	for _, p := range s.preds {
+		if !p.match(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p queryPred) match(n *Node) bool {
+	var text string
+	if p.meta {
+		if n.Meta == nil {
+			return p.op == "!="
+		}
+		text = sprint(n.Meta)
+	} else {
+		text = sprint(n.Value)
+	}
+	switch p.op {
+	case "=":
+		return text == p.text
+	case "!=":
+		return text != p.text
+	}
+	return p.re.MatchString(text)
+}
+
+type queryCompiler struct {
+	expr string
+	pos  int
+}
+
+func (c *queryCompiler) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w %q at offset %d: %s", ErrQuerySyntax, c.expr, c.pos, fmt.Sprintf(format, args...))
+}
+
+// step compiles the step starting at the current position, up to the next slash or the end.
+func (c *queryCompiler) step() (queryStep, error) {
+	var s queryStep
+	if c.peek() == '"' {
+		name, err := c.quoted()
+		if err != nil {
+			return s, err
+		}
+		s.name, s.literal = name, true
+	} else {
+		s.name = c.until("/[")
+		if s.name == "**" {
+			s.anyDepth = true
+		} else if _, err := path.Match(s.name, ""); err != nil {
+			return s, c.errorf("bad pattern %q", s.name)
+		}
+	}
+	if s.name == "" && !s.literal {
+		return s, c.errorf("empty step")
+	}
+	for c.peek() == '[' {
+		if s.anyDepth {
+			return s, c.errorf("predicate on **")
+		}
+		c.pos++
+		p, err := c.pred()
+		if err != nil {
+			return s, err
+		}
+		s.preds = append(s.preds, p)
+	}
+	if c.pos < len(c.expr) && c.peek() != '/' {
+		return s, c.errorf("unexpected %q", c.peek())
+	}
+	return s, nil
+}
+
+// pred compiles a predicate following its opening bracket.
+func (c *queryCompiler) pred() (queryPred, error) {
+	var p queryPred
+	switch field := c.until("=!~]"); field {
+	case "meta":
+		p.meta = true
+	case "value":
+	default:
+		return p, c.errorf("unknown field %q", field)
+	}
+	for _, op := range []string{"!=", "=", "~"} {
+		if strings.HasPrefix(c.expr[c.pos:], op) {
+			p.op = op
+			c.pos += len(op)
+			break
+		}
+	}
+	if p.op == "" {
+		return p, c.errorf("missing operator")
+	}
+	if c.peek() == '"' {
+		text, err := c.quoted()
+		if err != nil {
+			return p, err
+		}
+		p.text = text
+	} else {
+		p.text = c.until("]")
+	}
+	if c.peek() != ']' {
+		return p, c.errorf("missing ]")
+	}
+	c.pos++
+	if p.op == "~" {
+		re, err := regexp.Compile(p.text)
+		if err != nil {
+			return p, c.errorf("%v", err)
+		}
+		p.re = re
+	}
+	return p, nil
+}
+
+// quoted compiles the Go string starting at the current position.
+func (c *queryCompiler) quoted() (string, error) {
+	prefix, err := strconv.QuotedPrefix(c.expr[c.pos:])
+	if err != nil {
+		return "", c.errorf("bad quoted string")
+	}
+	c.pos += len(prefix)
+	s, _ := strconv.Unquote(prefix)
+	return s, nil
+}
+
+// until returns the text up to the first of the stop bytes, or up to the end.
+func (c *queryCompiler) until(stop string) string {
+	start := c.pos
+	for c.pos < len(c.expr) && strings.IndexByte(stop, c.expr[c.pos]) < 0 {
+		c.pos++
+	}
+	return c.expr[start:c.pos]
+}
+
+// peek returns the byte at the current position, or 0 at the end.
+func (c *queryCompiler) peek() byte {
+	if c.pos < len(c.expr) {
+		return c.expr[c.pos]
+	}
+	return 0
+}
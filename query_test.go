@@ -0,0 +1,63 @@
+package treeprint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	services := tree.AddBranch("services")
+	api := services.AddMetaBranch("prod", "api")
+	api.AddNode("main.go")
+	api.AddNode("api_test.go")
+	services.AddMetaBranch("staging", "web").AddNode("index.html")
+	services.AddMetaBranch("prod", "a/b")
+	tree.AddNode("README")
+
+	values := func(expr string) []Value {
+		selected, err := tree.Query(expr)
+		assert.NoError(err, expr)
+		var values []Value
+		for _, t := range selected {
+			values = append(values, t.(*Node).Value)
+		}
+		return values
+	}
+	assert.Equal([]Value{"api", "a/b"}, values("services/*[meta=prod]"))
+	assert.Equal([]Value{"main.go", "api_test.go"}, values("services/*[meta=prod]/*"))
+	assert.Equal([]Value{"web"}, values("services/*[meta!=prod]"))
+	assert.Equal([]Value{"api_test.go"}, values("**/*_test.go"))
+	assert.Equal([]Value{"main.go", "api_test.go"}, values("services/**/*.go"))
+	assert.Equal([]Value{"a/b"}, values(`services/"a/b"`))
+	assert.Equal([]Value{"api", "web"}, values(`services/*[meta~^(prod|staging)$][value!="a/b"]`))
+	assert.Equal([]Value{"README"}, values("README"))
+	assert.Nil(values("missing/*"))
+
+	q, err := CompileQuery("services/*")
+	assert.NoError(err)
+	assert.Equal("services/*", q.String())
+	assert.Len(q.Select(tree.(*Node)), 3)
+	assert.Len(q.Select(services.(*Node)), 3, "services is matched as the leading root step")
+	assert.Len(q.Select(api.(*Node)), 0)
+
+	root := NewWithRoot("root")
+	prod := root.AddMetaBranch("prod", "services")
+	prod.AddNode("api")
+	prod.AddNode("web")
+	root.AddMetaBranch("staging", "services").AddNode("db")
+	for _, expr := range []string{"root/services[meta=prod]/*", "services[meta=prod]/*"} {
+		selected, err := root.Query(expr)
+		assert.NoError(err)
+		assert.Equal([]Tree{prod.FindByValue("api"), prod.FindByValue("web")}, selected, expr)
+	}
+
+	for _, expr := range []string{"", "a/", "/a", "a[size=1]", "a[meta]", "a[meta=x", "a[meta~(]", "[x", "**[meta=x]", `"a`, "[-]"} {
+		_, err := tree.Query(expr)
+		assert.True(errors.Is(err, ErrQuerySyntax), expr)
+	}
+}
@@ -0,0 +1,167 @@
+package treeprint
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Shape is a tree whose string values, metas and descriptions may hold text/template
+// actions, as "cpu: {{.CPU}}%", compiled once and bound to new data as often as needed.
+// It suits status screens whose hierarchy is fixed while the values change on every refresh.
+// A Shape is safe for concurrent use.
+type Shape struct {
+	root    *shapeNode
+	options *PrinterOptions
+}
+
+// shapeNode is a Node of a Shape, its templates are nil for the static texts.
+type shapeNode struct {
+	meta, value, description *template.Template
+	staticMeta               MetaValue
+	staticValue              Value
+	staticDescription        string
+	status                   Status
+	children                 []*shapeNode
+}
+
+// CompileShape compiles the templates of the tree into a Shape. The tree can be changed
+// afterwards without affecting the Shape. It fails if a template doesn't parse.
+func CompileShape(t Tree) (*Shape, error) {
+	n := t.(*Node)
+	s := &Shape{}
+	if options := n.root().options; options != nil {
+		copied := *options
+		s.options = &copied
+	}
+	var err error
+	if s.root, err = compileShapeNode(n); err != nil {
+		return nil, err
+	}
+	type frame struct {
+		src *Node
+		dst *shapeNode
+	}
+	stack := []frame{{src: n, dst: s.root}}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, node := range top.src.children() {
+			child, err := compileShapeNode(node)
+			if err != nil {
+				return nil, err
+			}
+			top.dst.children = append(top.dst.children, child)
+			stack = append(stack, frame{src: node, dst: child})
+		}
+	}
+	return s, nil
+}
+
+func compileShapeNode(n *Node) (*shapeNode, error) {
+	sn := &shapeNode{
+		staticMeta:        n.Meta,
+		staticValue:       n.Value,
+		staticDescription: n.description,
+		status:            n.status,
+	}
+	var err error
+	if s, ok := n.Value.(string); ok {
+		if sn.value, err = compileShapeText(s); err != nil {
+			return nil, err
+		}
+	}
+	if s, ok := n.Meta.(string); ok {
+		if sn.meta, err = compileShapeText(s); err != nil {
+			return nil, err
+		}
+	}
+	if sn.description, err = compileShapeText(n.description); err != nil {
+		return nil, err
+	}
+	return sn, nil
+}
+
+// compileShapeText returns the template of s, or nil if s holds no action.
+func compileShapeText(s string) (*template.Template, error) {
+	if !strings.Contains(s, "{{") {
+		return nil, nil
+	}
+	t, err := template.New("").Option("missingkey=error").Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("treeprint: shape text %q: %w", s, err)
+	}
+	return t, nil
+}
+
+// Bind is like BindE, but a value, meta or description whose template fails
+// to execute is replaced with the error, as "%!(treeprint: ...)".
+func (s *Shape) Bind(data interface{}) Tree {
+	t, _ := s.bind(data, false)
+	return t
+}
+
+// BindE instantiates the Shape into a new tree, executing the templates with data,
+// usually a map or a struct. It fails on the first template failing to execute,
+// such as one referring to a missing map key or struct field.
+func (s *Shape) BindE(data interface{}) (Tree, error) {
+	return s.bind(data, true)
+}
+
+func (s *Shape) bind(data interface{}, strict bool) (Tree, error) {
+	var buf strings.Builder
+	exec := func(t *template.Template) (string, error) {
+		buf.Reset()
+		if err := t.Execute(&buf, data); err != nil {
+			err = fmt.Errorf("treeprint: bind shape: %w", err)
+			if strict {
+				return "", err
+			}
+			return "%!(" + err.Error() + ")", nil
+		}
+		return buf.String(), nil
+	}
+	fill := func(n *Node, sn *shapeNode) error {
+		n.Meta, n.Value, n.description, n.status = sn.staticMeta, sn.staticValue, sn.staticDescription, sn.status
+		var err error
+		if sn.value != nil {
+			if n.Value, err = exec(sn.value); err != nil {
+				return err
+			}
+		}
+		if sn.meta != nil {
+			if n.Meta, err = exec(sn.meta); err != nil {
+				return err
+			}
+		}
+		if sn.description != nil {
+			if n.description, err = exec(sn.description); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	root := &Node{options: s.options}
+	if err := fill(root, s.root); err != nil {
+		return nil, err
+	}
+	type frame struct {
+		src *shapeNode
+		dst *Node
+	}
+	stack := []frame{{src: s.root, dst: root}}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, sn := range top.src.children {
+			child := top.dst.newChild(nil, nil)
+			if err := fill(child, sn); err != nil {
+				return nil, err
+			}
+			top.dst.Nodes = append(top.dst.Nodes, child)
+			stack = append(stack, frame{src: sn, dst: child})
+		}
+	}
+	return root, nil
+}
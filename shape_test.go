@@ -0,0 +1,44 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShape(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewWithRoot("{{.Host}}")
+	cpu := tree.AddMetaBranch("{{.Load}}", "cpu")
+	cpu.AddNode("user: {{.User}}%")
+	cpu.AddNode(42)
+	tree.AddNode("uptime")
+	tree.FindByValue("uptime").SetDescription("since {{.Since}}")
+
+	shape, err := CompileShape(tree)
+	assert.NoError(err)
+	tree.AddNode("added later")
+
+	type stats struct {
+		Host, Load, Since string
+		User              int
+	}
+	assert.Equal(`web-1
+├── [0.5]  cpu
+│   ├── user: 12%
+│   └── 42
+└── uptime
+    since monday
+`, shape.Bind(stats{Host: "web-1", Load: "0.5", User: 12, Since: "monday"}).String())
+	bound, err := shape.BindE(map[string]interface{}{"Host": "web-2", "Load": 1, "User": 99, "Since": "today"})
+	assert.NoError(err)
+	assert.Equal("web-2\n├── [1]  cpu\n│   ├── user: 99%\n│   └── 42\n└── uptime\n    since today\n", bound.String())
+
+	_, err = shape.BindE(map[string]interface{}{"Host": "web-3"})
+	assert.Error(err)
+	assert.Contains(shape.Bind(map[string]interface{}{"Host": "web-3"}).String(), "├── [%!(treeprint: bind shape: ")
+
+	_, err = CompileShape(NewWithRoot("{{.Host"))
+	assert.Error(err)
+}
@@ -0,0 +1,147 @@
+//go:build go1.21
+
+package treeprint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+// LogValue implements slog.LogValuer, the tree is logged as nested groups mirroring
+// the hierarchy. Every child becomes an attribute keyed by its value: the one of
+// a branch is a group of its children, the one of a leaf is its meta, or an empty
+// string. The meta of a branch is logged in the group as the "@meta" attribute.
+// The keys of the children are unique within their group, a key already taken
+// is suffixed with the index of the child, as "a#2".
+// A nil Node is logged as an empty group.
+func (n *Node) LogValue() slog.Value {
+	if n == nil {
+		return slog.GroupValue()
+	}
+	type frame struct {
+		node  *Node
+		i     int
+		attrs []slog.Attr
+		keys  map[string]bool
+	}
+	newFrame := func(node *Node) frame {
+		f := frame{node: node, keys: make(map[string]bool)}
+		if node.Meta != nil {
+			f.attrs = []slog.Attr{slog.Any("@meta", node.Meta)}
+			f.keys["@meta"] = true
+		}
+		return f
+	}
+	stack := []frame{newFrame(n)}
+	// a Node reached again from one of its own descendants is logged as a leaf
+	path := map[*Node]bool{n: true}
+	for {
+		top := &stack[len(stack)-1]
+		children := top.node.children()
+		if top.i == len(children) {
+			group := slog.GroupValue(top.attrs...)
+			delete(path, top.node)
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return group
+			}
+			parent := &stack[len(stack)-1]
+			parent.attrs = append(parent.attrs, slog.Attr{Key: logKey(top.node, parent.i-1, parent.keys), Value: group})
+			continue
+		}
+		node := children[top.i]
+		top.i++
+		if path[node] || len(node.children()) == 0 {
+			value := slog.StringValue("")
+			if node.Meta != nil {
+				value = slog.AnyValue(node.Meta)
+			}
+			top.attrs = append(top.attrs, slog.Attr{Key: logKey(node, top.i-1, top.keys), Value: value})
+			continue
+		}
+		path[node] = true
+		stack = append(stack, newFrame(node))
+	}
+}
+
+// logKey returns the key of the i-th child n, unique among the keys already taken.
+func logKey(n *Node, i int, taken map[string]bool) string {
+	key, ok := n.Value.(string)
+	if !ok {
+		key = fmt.Sprint(n.Value)
+	}
+	for unique := key; ; unique += "#" + strconv.Itoa(i) {
+		if !taken[unique] {
+			taken[unique] = true
+			return unique
+		}
+	}
+}
+
+// LogTree logs the tree under the key at the given level, as a value encoded by the
+// handler: the handlers encoding values as JSON, through json.Marshaler, get the tree
+// as nested objects shaped as the groups of LogValue, the others, through
+// encoding.TextMarshaler, get it as a text block rendered as String does.
+func LogTree(ctx context.Context, logger *slog.Logger, level slog.Level, msg, key string, t Tree) {
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	n, _ := t.(*Node)
+	logger.LogAttrs(ctx, level, msg, slog.Any(key, loggedTree{n: n}))
+}
+
+// loggedTree is the value logged by LogTree.
+type loggedTree struct {
+	n *Node
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t loggedTree) MarshalText() ([]byte, error) {
+	return []byte(t.n.String()), nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t loggedTree) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := appendLogJSON(&buf, t.n.LogValue()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// appendLogJSON writes v as JSON, the groups as objects keeping the order of their attributes.
+func appendLogJSON(buf *bytes.Buffer, v slog.Value) error {
+	enc := json.NewEncoder(buf)
+	// as slog.JSONHandler does
+	enc.SetEscapeHTML(false)
+	encode := func(v interface{}) error {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		// without the newline ending every encoded value
+		buf.Truncate(buf.Len() - 1)
+		return nil
+	}
+	if v.Kind() != slog.KindGroup {
+		return encode(v.Any())
+	}
+	buf.WriteByte('{')
+	for i, a := range v.Group() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := encode(a.Key); err != nil {
+			return err
+		}
+		buf.WriteByte(':')
+		if err := appendLogJSON(buf, a.Value.Resolve()); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
@@ -0,0 +1,68 @@
+//go:build go1.21
+
+package treeprint
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogValue(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	one := tree.AddMetaBranch("m", "one")
+	one.AddMetaNode(1, "a")
+	one.AddNode("b")
+	tree.AddNode("two")
+
+	// the time is dropped to get a stable output
+	opts := &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, opts))
+	LogTree(context.Background(), logger, slog.LevelInfo, "msg", "tree", tree)
+	assert.Equal(`{"level":"INFO","msg":"msg","tree":{"one":{"@meta":"m","a":1,"b":""},"two":""}}`+"\n", buf.String())
+
+	buf.Reset()
+	logger = slog.New(slog.NewTextHandler(buf, opts))
+	LogTree(context.Background(), logger, slog.LevelInfo, "msg", "tree", tree)
+	assert.Equal(`level=INFO msg=msg tree="`+
+		`.\n├── [m]  one\n│   ├── [1]  a\n│   └── b\n└── two\n"`+"\n", buf.String())
+
+	buf.Reset()
+	LogTree(context.Background(), logger, slog.LevelDebug, "msg", "tree", tree)
+	assert.Empty(buf.String())
+
+	buf.Reset()
+	logger = slog.New(slog.NewJSONHandler(buf, opts))
+	logger.Info("msg", "tree", cyclicTree())
+	assert.Equal(`{"level":"INFO","msg":"msg","tree":{"a":{"b":{"c":"","a":""},".":""}}}`+"\n", buf.String())
+
+	// the handler is not a slog.JSONHandler, the repeated values are qualified by their index
+	buf.Reset()
+	logger = slog.New(wrappedHandler{slog.NewJSONHandler(buf, opts)})
+	tree.AddMetaNode("<m>", "two")
+	tree.AddNode("two#2")
+	LogTree(context.Background(), logger, slog.LevelInfo, "msg", "tree", tree)
+	assert.Equal(`{"level":"INFO","msg":"msg","tree":{"one":{"@meta":"m","a":1,"b":""},"two":"","two#2":"<m>","two#2#3":""}}`+"\n", buf.String())
+
+	buf.Reset()
+	var nilNode *Node
+	assert.NotPanics(func() { logger.Info("msg", "tree", nilNode) })
+	assert.Equal(`{"level":"INFO","msg":"msg"}`+"\n", buf.String())
+}
+
+type wrappedHandler struct {
+	slog.Handler
+}
@@ -0,0 +1,167 @@
+package treeprint
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// LessFunc function type for ordering sibling nodes, it reports whether a goes before b.
+type LessFunc func(a, b *Node) bool
+
+// WithSort renders the children of every Node in the order given by less,
+// leaving the tree itself untouched. Equal siblings keep their order.
+func WithSort(less LessFunc) Option {
+	return func(p *PrinterOptions) {
+		p.less = less
+	}
+}
+
+// SortChildren sorts the children of n, and those of all its descendants, by less.
+// Equal siblings keep their order.
+func (n *Node) SortChildren(less LessFunc) {
+	if n == nil {
+		return
+	}
+	sortNodes := func(node *Node) {
+		nodes := node.children()
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return less(nodes[i], nodes[j])
+		})
+		for i, child := range nodes {
+			child.index = i
+		}
+		node.MarkDirty()
+	}
+	sortNodes(n)
+	stack := []walkFrame{{parent: n}}
+	// a Node reached again from one of its own descendants is sorted already
+	path := map[*Node]bool{n: true}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.parent.Nodes) {
+			delete(path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.parent.Nodes[top.i]
+		top.i++
+		if path[node] {
+			continue
+		}
+		path[node] = true
+		sortNodes(node)
+		stack = append(stack, walkFrame{parent: node})
+	}
+}
+
+// children returns the children of n in the order they are rendered in, see WithSort.
+func (p PrinterOptions) children(n *Node) []*Node {
+	nodes := n.children()
+	if p.less == nil || len(nodes) < 2 {
+		return nodes
+	}
+	nodes = append([]*Node(nil), nodes...)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return p.less(nodes[i], nodes[j])
+	})
+	return nodes
+}
+
+// ByValue orders the nodes by their value, compared as strings.
+func ByValue(a, b *Node) bool {
+	return sprint(a.Value) < sprint(b.Value)
+}
+
+// CaseInsensitive orders the nodes by their value, compared as strings regardless of case.
+func CaseInsensitive(a, b *Node) bool {
+	return compareFold(sprint(a.Value), sprint(b.Value)) < 0
+}
+
+// Natural orders the nodes by their value, compared as strings where the runs of digits
+// compare as numbers, so that "file2" goes before "file10".
+func Natural(a, b *Node) bool {
+	return compareNatural(sprint(a.Value), sprint(b.Value)) < 0
+}
+
+// ByMeta orders the nodes by their meta value, compared as Natural does.
+// The nodes without a meta value go last.
+func ByMeta(a, b *Node) bool {
+	if a.Meta == nil || b.Meta == nil {
+		return a.Meta != nil
+	}
+	return compareNatural(sprint(a.Meta), sprint(b.Meta)) < 0
+}
+
+// BranchesFirst orders the branches before the leaves, as file managers list
+// directories before files, and the nodes of each kind by then.
+func BranchesFirst(then LessFunc) LessFunc {
+	return func(a, b *Node) bool {
+		ab, bb := len(a.children()) > 0, len(b.children()) > 0
+		if ab != bb {
+			return ab
+		}
+		return then(a, b)
+	}
+}
+
+// compareFold compares a and b rune by rune after case folding, falling back to a plain comparison.
+func compareFold(a, b string) int {
+	if c := strings.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// compareNatural compares a and b, comparing the runs of digits by their numeric value.
+func compareNatural(a, b string) int {
+	x, y := a, b
+	for x != "" && y != "" {
+		if isDigit(x[0]) && isDigit(y[0]) {
+			i, j := digits(x), digits(y)
+			nx, ny := strings.TrimLeft(x[:i], "0"), strings.TrimLeft(y[:j], "0")
+			if len(nx) != len(ny) {
+				return sign(len(nx) - len(ny))
+			}
+			if c := strings.Compare(nx, ny); c != 0 {
+				return c
+			}
+			x, y = x[i:], y[j:]
+			continue
+		}
+		rx, sx := utf8.DecodeRuneInString(x)
+		ry, sy := utf8.DecodeRuneInString(y)
+		if rx != ry {
+			return sign(int(rx) - int(ry))
+		}
+		x, y = x[sx:], y[sy:]
+	}
+	if c := sign(len(x) - len(y)); c != 0 {
+		return c
+	}
+	// equal up to leading zeros
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// digits returns the length of the run of digits s starts with.
+func digits(s string) int {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+package treeprint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortChildren(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddNode("file10")
+	src := tree.AddBranch("src")
+	src.AddNode("b.go")
+	src.AddNode("A.go")
+	tree.AddNode("file2")
+	tree.AddBranch("Docs").AddNode("x")
+
+	tree.SortChildren(BranchesFirst(CaseInsensitive))
+	assert.Equal(`.
+├── Docs
+│   └── x
+├── src
+│   ├── A.go
+│   └── b.go
+├── file10
+└── file2
+`, tree.String())
+	assert.Equal(1, tree.FindByValue("src").Index())
+
+	tree.SortChildren(Natural)
+	assert.Equal(`.
+├── Docs
+│   └── x
+├── file2
+├── file10
+└── src
+    ├── A.go
+    └── b.go
+`, tree.String())
+}
+
+func TestWithSort(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddMetaNode(3, "c")
+	tree.AddNode("none")
+	tree.AddMetaNode(1, "a")
+	tree.AddMetaBranch(2, "b").AddNode("z").AddNode("y")
+
+	want := `.
+├── [1]  a
+├── [2]  b
+│   ├── y
+│   └── z
+├── [3]  c
+└── none
+`
+	f := NewPrinter(WithSort(BranchesFirst(ByMeta)))
+	assert.Equal(`.
+├── [2]  b
+│   ├── z
+│   └── y
+├── [1]  a
+├── [3]  c
+└── none
+`, string(tree.Bytes(f)))
+
+	f = NewPrinter(WithSort(func(a, b *Node) bool {
+		if a.Meta != nil && b.Meta != nil {
+			return ByMeta(a, b)
+		}
+		return ByValue(a, b)
+	}))
+	assert.Equal(want, string(tree.Bytes(f)))
+	// the tree itself is left untouched
+	assert.Equal("c", tree.(*Node).Nodes[0].Value)
+
+	var buf bytes.Buffer
+	_, err := tree.(*Node).PrintToParallel(&buf, f, 2)
+	assert.NoError(err)
+	assert.Equal(want, buf.String())
+
+	buf.Reset()
+	r := NewIncrementalPrinter(WithSort(ByMeta))
+	_, err = r.Render(&buf, tree.(*Node))
+	assert.NoError(err)
+	assert.Equal(".\n├── [1]  a\n├── [2]  b\n│   ├── z\n│   └── y\n├── [3]  c\n└── none\n", buf.String())
+}
+
+func TestCompareNatural(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(-1, compareNatural("file2", "file10"))
+	assert.Equal(1, compareNatural("v1.10", "v1.9"))
+	assert.Equal(-1, compareNatural("a01", "a1"))
+	assert.Equal(0, compareNatural("a1b", "a1b"))
+	assert.Equal(-1, compareNatural("a", "a1"))
+	assert.Equal(-1, compareNatural("Ä1", "Ä2"))
+}
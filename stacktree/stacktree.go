@@ -0,0 +1,222 @@
+// Package stacktree aggregates goroutine stack dumps into call trees rendered by treeprint.
+//
+// Both the runtime.Stack format, which is also the one of the pprof goroutine
+// profile with debug=2, and the pprof goroutine profile with debug=1 are understood.
+// The goroutines are merged by their common callers: every Node of the tree is
+// a function, with the number of goroutines going through it as the meta.
+package stacktree
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ououmania/treeprint"
+)
+
+// Goroutines returns the call tree of all the goroutines of the current process,
+// or the error of parsing their stacks, would the runtime change their format.
+func Goroutines() (treeprint.Tree, error) {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	return Parse(bytes.NewReader(buf))
+}
+
+// Parse reads a stack dump and returns its call tree. The root is "goroutines"
+// with the total number of goroutines as the meta, its children are the outermost
+// callers, such as main.main or the "created by" functions of the goroutines.
+func Parse(r io.Reader) (treeprint.Tree, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	root := &call{}
+	var err error
+	for s.Scan() {
+		line := s.Text()
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "goroutine profile:") {
+			err = parseProfile(s, root)
+		} else {
+			err = parseStacks(s, line, root)
+		}
+		break
+	}
+	if err == nil {
+		err = s.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return root.tree(), nil
+}
+
+// call aggregates the goroutines going through a function from the same callers.
+type call struct {
+	count    int
+	children map[string]*call
+}
+
+// add records count goroutines with the given frames, the innermost one first.
+func (c *call) add(frames []string, count int) {
+	c.count += count
+	for i := len(frames) - 1; i >= 0; i-- {
+		if c.children == nil {
+			c.children = make(map[string]*call)
+		}
+		child, ok := c.children[frames[i]]
+		if !ok {
+			child = &call{}
+			c.children[frames[i]] = child
+		}
+		child.count += count
+		c = child
+	}
+}
+
+// tree builds the treeprint tree, the busiest calls first.
+func (c *call) tree() treeprint.Tree {
+	root := treeprint.NewWithRoot("goroutines")
+	root.SetMetaValue(c.count)
+	type entry struct {
+		call *call
+		node treeprint.Tree
+	}
+	stack := []entry{{call: c, node: root}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		names := make([]string, 0, len(e.call.children))
+		for name := range e.call.children {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			ci, cj := e.call.children[names[i]], e.call.children[names[j]]
+			if ci.count != cj.count {
+				return ci.count > cj.count
+			}
+			return names[i] < names[j]
+		})
+		for _, name := range names {
+			child := e.call.children[name]
+			if len(child.children) == 0 {
+				e.node.AddMetaNode(child.count, name)
+				continue
+			}
+			stack = append(stack, entry{call: child, node: e.node.AddMetaBranch(child.count, name)})
+		}
+	}
+	return root
+}
+
+// parseStacks parses the runtime.Stack format, where every goroutine starts with
+// a "goroutine N [state]:" header, followed by a function and a file line per frame.
+func parseStacks(s *bufio.Scanner, header string, root *call) error {
+	var frames []string
+	lineNo := 1
+	if !strings.HasPrefix(header, "goroutine ") {
+		return fmt.Errorf("stacktree: line %d: goroutine header expected", lineNo)
+	}
+	for s.Scan() {
+		lineNo++
+		line := s.Text()
+		switch {
+		case line == "":
+			root.add(frames, 1)
+			frames = frames[:0]
+			// a header follows
+			for s.Scan() {
+				lineNo++
+				if line = s.Text(); line != "" {
+					break
+				}
+			}
+			if line != "" && !strings.HasPrefix(line, "goroutine ") {
+				return fmt.Errorf("stacktree: line %d: goroutine header expected", lineNo)
+			}
+		case strings.HasPrefix(line, "\t"), strings.HasPrefix(line, "...additional frames elided"):
+			// file lines
+		case strings.HasPrefix(line, "created by "):
+			name := strings.TrimPrefix(line, "created by ")
+			if i := strings.Index(name, " in goroutine "); i >= 0 {
+				name = name[:i]
+			}
+			frames = append(frames, "created by "+name)
+		default:
+			frames = append(frames, trimArgs(line))
+		}
+	}
+	if len(frames) > 0 {
+		root.add(frames, 1)
+	}
+	return nil
+}
+
+// trimArgs removes the arguments of a function line, as in "main.(*T).f(0x1, ...)".
+func trimArgs(line string) string {
+	if strings.HasSuffix(line, ")") {
+		if i := strings.LastIndexByte(line, '('); i > 0 {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseProfile parses the pprof goroutine profile with debug=1, where every group
+// of identical goroutines starts with a "N @ addresses" header, followed by
+// a "#\taddress\tfunction+offset\tfile:line" line per frame.
+func parseProfile(s *bufio.Scanner, root *call) error {
+	var (
+		frames []string
+		count  int
+		lineNo = 1
+	)
+	for s.Scan() {
+		lineNo++
+		line := s.Text()
+		switch {
+		case line == "":
+			if count > 0 {
+				root.add(frames, count)
+			}
+			frames, count = frames[:0], 0
+		case strings.HasPrefix(line, "# labels:"):
+		case strings.HasPrefix(line, "#\t"):
+			fields := strings.Split(line, "\t")
+			if len(fields) < 3 {
+				return fmt.Errorf("stacktree: line %d: malformed frame", lineNo)
+			}
+			name := fields[2]
+			if i := strings.LastIndex(name, "+0x"); i > 0 {
+				name = name[:i]
+			}
+			frames = append(frames, name)
+		default:
+			i := strings.Index(line, " @ ")
+			if i < 0 {
+				return fmt.Errorf("stacktree: line %d: goroutine count expected", lineNo)
+			}
+			n, err := strconv.Atoi(line[:i])
+			if err != nil {
+				return fmt.Errorf("stacktree: line %d: %w", lineNo, err)
+			}
+			count = n
+		}
+	}
+	if count > 0 {
+		root.add(frames, count)
+	}
+	return nil
+}
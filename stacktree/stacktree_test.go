@@ -0,0 +1,92 @@
+package stacktree
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const stacks = `goroutine 1 [running]:
+main.main()
+	/src/main.go:12 +0x1d
+
+goroutine 7 [chan receive]:
+main.(*worker).run(0xc000010000, {0x1, 0x2})
+	/src/worker.go:30 +0x45
+created by main.start in goroutine 1
+	/src/worker.go:12 +0x65
+
+goroutine 8 [chan receive]:
+main.(*worker).run(0xc000010010, {0x1, 0x2})
+	/src/worker.go:30 +0x45
+created by main.start in goroutine 1
+	/src/worker.go:12 +0x65
+
+goroutine 9 [select]:
+main.(*worker).wait(...)
+	/src/worker.go:50
+main.(*worker).run(0xc000010020, {0x1, 0x2})
+	/src/worker.go:34 +0x45
+created by main.start in goroutine 1
+	/src/worker.go:12 +0x65
+`
+
+const profile = `goroutine profile: total 3
+2 @ 0x43e7b6 0x40a9d2 0x46b5c0
+#	0x43e7b5	runtime.gopark+0xd5	/go/src/runtime/proc.go:398
+#	0x40a9d1	main.(*worker).run+0x51	/src/worker.go:30
+# labels: {"pool":"a"}
+
+1 @ 0x46b5c0 0x46b5c1
+#	0x46b5bf	runtime/pprof.writeGoroutine+0x1f	/go/src/runtime/pprof/pprof.go:700
+#	0x46b5c0	main.main+0x20	/src/main.go:12
+`
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+
+	tree, err := Parse(strings.NewReader(stacks))
+	assert.NoError(err)
+	assert.Equal(`[4]  goroutines
+├── [3]  created by main.start
+│   └── [3]  main.(*worker).run
+│       └── [1]  main.(*worker).wait
+└── [1]  main.main
+`, tree.String())
+
+	tree, err = Parse(strings.NewReader(profile))
+	assert.NoError(err)
+	assert.Equal(`[3]  goroutines
+├── [2]  main.(*worker).run
+│   └── [2]  runtime.gopark
+└── [1]  main.main
+    └── [1]  runtime/pprof.writeGoroutine
+`, tree.String())
+
+	_, err = Parse(strings.NewReader("main.main()\n"))
+	assert.EqualError(err, "stacktree: line 1: goroutine header expected")
+	_, err = Parse(strings.NewReader("goroutine profile: total 1\nbad\n"))
+	assert.EqualError(err, "stacktree: line 2: goroutine count expected")
+}
+
+func TestGoroutines(t *testing.T) {
+	assert := assert.New(t)
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-done
+		}()
+	}
+	tree, err := Goroutines()
+	assert.NoError(err)
+	close(done)
+	wg.Wait()
+
+	assert.Contains(tree.String(), "[3]  created by github.com/ououmania/treeprint/stacktree.TestGoroutines")
+}
@@ -0,0 +1,141 @@
+package treeprint
+
+import "bytes"
+
+// Style is a text style rendered with ANSI escape sequences, made of the SGR parameters
+// of the sequence, as "1;31" for bold red. Styles combine with With.
+type Style string
+
+// The text attributes and foreground colors most terminals support.
+const (
+	Bold      Style = "1"
+	Dim       Style = "2"
+	Italic    Style = "3"
+	Underline Style = "4"
+	Reverse   Style = "7"
+	Red       Style = "31"
+	Green     Style = "32"
+	Yellow    Style = "33"
+	Blue      Style = "34"
+	Magenta   Style = "35"
+	Cyan      Style = "36"
+)
+
+// With returns the combination of both styles.
+func (s Style) With(other Style) Style {
+	if s == "" {
+		return other
+	}
+	if other == "" {
+		return s
+	}
+	return s + ";" + other
+}
+
+// appendStyled appends text rendered with the style to dst. The style is reset
+// at the end of every line and set again at the start of the next one,
+// so that the edges of the tree between them are not styled. It is also set
+// again after the resets within text, so that styled parts can be nested.
+func (s Style) appendStyled(dst, text []byte) []byte {
+	if s == "" || len(text) == 0 {
+		return append(dst, text...)
+	}
+	for {
+		i := bytes.IndexByte(text, '\n')
+		line := text
+		if i >= 0 {
+			line = text[:i]
+		}
+		if len(line) > 0 {
+			dst = s.appendStart(dst)
+			for {
+				j := bytes.Index(line, sgrReset)
+				if j < 0 {
+					break
+				}
+				dst = append(dst, line[:j+len(sgrReset)]...)
+				dst = s.appendStart(dst)
+				line = line[j+len(sgrReset):]
+			}
+			dst = append(dst, line...)
+			dst = append(dst, sgrReset...)
+		}
+		if i < 0 {
+			return dst
+		}
+		dst = append(dst, '\n')
+		text = text[i+1:]
+	}
+}
+
+var sgrReset = []byte("\x1b[0m")
+
+// appendStart appends the escape sequence setting the style.
+func (s Style) appendStart(dst []byte) []byte {
+	dst = append(dst, "\x1b["...)
+	dst = append(dst, s...)
+	return append(dst, 'm')
+}
+
+// StyleRule styles the nodes it matches, see WithStyleRules.
+type StyleRule struct {
+	// Match reports whether the rule applies to the Node, given its depth: 0 for the Node
+	// the rendering starts from, 1 for its children and so on. Like a RenderHook, it
+	// must not modify the Node it is given.
+	Match func(n *Node, depth int) bool
+	Style Style
+}
+
+// WithStyleRules styles the meta and value of every Node with the combination
+// of the styles of all the rules matching it, in order.
+func WithStyleRules(rules ...StyleRule) Option {
+	return func(p *PrinterOptions) {
+		p.styleRules = append(p.styleRules, rules...)
+	}
+}
+
+// AtDepth matches the nodes of the given depth.
+func AtDepth(depth int) func(*Node, int) bool {
+	return func(_ *Node, d int) bool {
+		return d == depth
+	}
+}
+
+// Leaves matches the nodes without children.
+func Leaves(n *Node, _ int) bool {
+	return len(n.children()) == 0
+}
+
+// Branches matches the nodes with children.
+func Branches(n *Node, _ int) bool {
+	return len(n.children()) > 0
+}
+
+// MatchedBy matches the nodes matched by m, as Find does.
+func MatchedBy(m Matcher) func(*Node, int) bool {
+	return func(n *Node, _ int) bool {
+		return m.MatchValue(n.Value) && m.MatchMeta(n.Meta)
+	}
+}
+
+// styled returns the formatted meta and value of the Node styled by the matching rules,
+// see WithStyleRules. The result is only valid until the next call.
+func (p *printer) styled(n *Node, depth int, meta, value []byte) ([]byte, []byte) {
+	var style Style
+	for _, rule := range p.pf.styleRules {
+		if rule.Match(n, depth) {
+			style = style.With(rule.Style)
+		}
+	}
+	if style == "" {
+		return meta, value
+	}
+	// the separator following the meta value is left unstyled
+	trimmed := bytes.TrimRight(meta, " ")
+	b := style.appendStyled(p.ruled[:0], trimmed)
+	b = append(b, meta[len(trimmed):]...)
+	i := len(b)
+	b = style.appendStyled(b, value)
+	p.ruled = b
+	return b[:i:i], b[i:]
+}
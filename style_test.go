@@ -0,0 +1,42 @@
+package treeprint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyleRules(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewWithRoot("build")
+	steps := tree.AddBranch("steps")
+	steps.AddNode("compile")
+	steps.AddMetaNode(errors.New("exit 1"), "test")
+
+	failed := func(n *Node, _ int) bool {
+		_, ok := n.Meta.(error)
+		return ok
+	}
+	f := NewPrinter(WithStyleRules(
+		StyleRule{Match: AtDepth(0), Style: Bold},
+		StyleRule{Match: Leaves, Style: Dim},
+		StyleRule{Match: failed, Style: Red},
+	))
+	assert.Equal("\x1b[1mbuild\x1b[0m\n"+
+		"└── steps\n"+
+		"    ├── \x1b[2mcompile\x1b[0m\n"+
+		"    └── \x1b[2;31m[exit 1]\x1b[0m  \x1b[2;31mtest\x1b[0m\n", string(tree.Bytes(f)))
+
+	// the styles nest with the highlighted parts
+	f = NewPrinter(WithStyleRules(StyleRule{Match: Branches, Style: Blue}), HighlightMatches(Contains("ep"), Reverse))
+	assert.Equal("\x1b[34mbuild\x1b[0m\n"+
+		"└── \x1b[34mst\x1b[7mep\x1b[0m\x1b[34ms\x1b[0m\n"+
+		"    ├── compile\n"+
+		"    └── [exit 1]  test\n", string(tree.Bytes(f)))
+
+	assert.True(MatchedBy(Equal("test"))(steps.FindByValue("test").(*Node), 2))
+	assert.Equal(Bold.With(Red), Style("1;31"))
+	assert.Equal(Style("").With(Red), Red)
+}
@@ -0,0 +1,152 @@
+package treeprint
+
+import (
+	"io"
+	"sync"
+)
+
+// SyncTree is a handle over a tree guarding it with a sync.RWMutex, so several goroutines
+// may add nodes to it and render it at the same time. The branches returned by AddBranch
+// and AddMetaBranch share the lock of the whole tree.
+// The tree must not be modified through other references while SyncTree handles are in use.
+type SyncTree struct {
+	mu *sync.RWMutex
+	n  *Node
+}
+
+// NewSyncTree creates a new tree guarded by a lock.
+func NewSyncTree() *SyncTree {
+	return Synchronized(New())
+}
+
+// Synchronized returns a handle guarding the tree t with a new lock.
+func Synchronized(t Tree) *SyncTree {
+	return &SyncTree{mu: new(sync.RWMutex), n: t.(*Node)}
+}
+
+// AddNode adds a new Node to the branch, returning the branch itself.
+func (s *SyncTree) AddNode(v Value) *SyncTree {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.n.AddNode(v)
+	return s
+}
+
+// AddMetaNode adds a new Node with meta value provided to the branch, returning the branch itself.
+func (s *SyncTree) AddMetaNode(meta MetaValue, v Value) *SyncTree {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.n.AddMetaNode(meta, v)
+	return s
+}
+
+// AddBranch adds a new branch Node (a level deeper), returning a handle sharing the same lock.
+func (s *SyncTree) AddBranch(v Value) *SyncTree {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &SyncTree{mu: s.mu, n: s.n.AddBranch(v).(*Node)}
+}
+
+// AddMetaBranch adds a new branch Node (a level deeper) with meta value provided,
+// returning a handle sharing the same lock.
+func (s *SyncTree) AddMetaBranch(meta MetaValue, v Value) *SyncTree {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &SyncTree{mu: s.mu, n: s.n.AddMetaBranch(meta, v).(*Node)}
+}
+
+// SetValue sets the value of the Node.
+func (s *SyncTree) SetValue(value Value) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.n.SetValue(value)
+}
+
+// SetMetaValue sets the meta value of the Node.
+func (s *SyncTree) SetMetaValue(meta MetaValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.n.SetMetaValue(meta)
+}
+
+// Update calls fn holding the write lock, for the changes not covered by the other methods.
+// The Tree passed to fn must not be retained after fn returns.
+func (s *SyncTree) Update(fn func(t Tree)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.n)
+}
+
+// View calls fn holding the read lock, fn must not modify the tree nor retain it after returning.
+func (s *SyncTree) View(fn func(t Tree)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(s.n)
+}
+
+// lock takes the lock needed to render with the printer options f: rendering through a
+// ValueCache stores the formatted values in the nodes and rendering lazy children
+// stores them in their parent, so either needs the write lock.
+func (s *SyncTree) lock(f PrinterOptions) func() {
+	if f.valueCache == nil {
+		s.mu.RLock()
+		if !hasLazyChildren(s.n) {
+			return s.mu.RUnlock
+		}
+		s.mu.RUnlock()
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+// hasLazyChildren reports whether a Node of the tree rooted at n has children not produced yet.
+func hasLazyChildren(n *Node) bool {
+	if n.lazy.pending() {
+		return true
+	}
+	stack := []walkFrame{{parent: n}}
+	path := map[*Node]bool{n: true}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.parent.Nodes) {
+			delete(path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.parent.Nodes[top.i]
+		top.i++
+		if node.lazy.pending() {
+			return true
+		}
+		if !path[node] && len(node.Nodes) > 0 {
+			path[node] = true
+			stack = append(stack, walkFrame{parent: node})
+		}
+	}
+	return false
+}
+
+// String renders the tree or subtree as a string with the printer options of the tree.
+func (s *SyncTree) String() string {
+	defer s.lock(s.n.Options())()
+	return s.n.String()
+}
+
+// Print renders the tree or subtree as a string using the given printer options.
+func (s *SyncTree) Print(f PrinterOptions) string {
+	defer s.lock(f)()
+	return s.n.Print(f)
+}
+
+// Bytes renders the tree or subtree as byteslice using the given printer options.
+func (s *SyncTree) Bytes(f PrinterOptions) []byte {
+	defer s.lock(f)()
+	return s.n.Bytes(f)
+}
+
+// PrintTo renders the tree or subtree into w using the given printer options,
+// the tree stays locked until the whole output is written.
+func (s *SyncTree) PrintTo(w io.Writer, f PrinterOptions) (int64, error) {
+	defer s.lock(f)()
+	return s.n.PrintTo(w, f)
+}
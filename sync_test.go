@@ -0,0 +1,73 @@
+package treeprint
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncTree(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := NewSyncTree()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			branch := tree.AddBranch(fmt.Sprintf("dir%d", i))
+			for j := 0; j < 50; j++ {
+				branch.AddMetaNode(j, "file")
+				_ = tree.String()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	tree.View(func(t Tree) {
+		assert.Equal(8, t.ChildCount())
+		for _, node := range t.NodesAtDepth(1) {
+			assert.Len(node.Nodes, 50)
+		}
+	})
+	tree.Update(func(t Tree) {
+		t.Prune(func(n *Node) bool { return n.Value == "file" })
+	})
+	out := tree.Print(NewPrinter(WithValueCache(NewValueCache())))
+	assert.Equal(8, strings.Count(out, "\n"))
+	assert.NotContains(out, "file")
+}
+
+func TestSyncTreeLazy(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := Synchronized(NewWithRoot(".", WithValueCache(NewValueCache())))
+	tree.AddBranch("cached").AddNode("leaf")
+	lazy := NewSyncTree()
+	lazy.Update(func(t Tree) {
+		for i := 0; i < 4; i++ {
+			branch := t.AddBranch(i).(*Node)
+			branch.SetChildrenFunc(func() []*Node {
+				return []*Node{{Value: "a"}, {Value: "b"}}
+			})
+		}
+	})
+
+	// run with -race, rendering stores the lazy children and the cached values
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = lazy.String()
+			_ = lazy.Bytes(NewPrinter())
+			_ = tree.String()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(13, strings.Count(lazy.String(), "\n"))
+	assert.Equal(".\n└── cached\n    └── leaf\n", tree.String())
+}
@@ -0,0 +1,158 @@
+package treeprint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TemplateFuncs returns the functions to use trees from text/template and html/template:
+//
+//	treeString TREE              renders the tree with the default printer options
+//	treeASCII TREE               renders the tree with ASCIIEdgeStyle
+//	treeLines TREE               renders the tree as a list of lines without line breaks
+//	treeMaxDepth DEPTH TREE      renders the tree down to the given depth
+//	treeSort TREE                returns a copy of the tree with every level sorted by value
+//	treeFilter SUBSTRING TREE    returns a copy of the tree with only the nodes whose value
+//	                             contains the substring, along with their ancestors and subtrees
+//
+// The tree comes last, so that the functions can be chained in pipelines:
+//
+//	{{.Tree | treeFilter "api" | treeSort | treeString}}
+//
+// The result can be passed to the Funcs method of both template packages.
+func TemplateFuncs() map[string]interface{} {
+	return map[string]interface{}{
+		"treeString": func(t Tree) string {
+			return t.String()
+		},
+		"treeASCII": func(t Tree) string {
+			return string(t.Bytes(NewPrinter(WithEdgeStyle(ASCIIEdgeStyle))))
+		},
+		"treeLines": func(t Tree) []string {
+			return strings.Split(strings.TrimSuffix(t.String(), "\n"), "\n")
+		},
+		"treeMaxDepth": func(depth int, t Tree) string {
+			return string(t.Bytes(NewPrinter(WithMaxDepth(depth))))
+		},
+		"treeSort": func(t Tree) Tree {
+			n := copyTree(t.(*Node))
+			sortNodes(n)
+			n.VisitPreOrder(sortNodes)
+			return n
+		},
+		"treeFilter": func(substr string, t Tree) Tree {
+			return copyMatching(t.(*Node), 0, func(item *Node) bool {
+				return strings.Contains(valueString(item), substr)
+			})
+		},
+	}
+}
+
+func valueString(n *Node) string {
+	if s, ok := n.Value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(n.Value)
+}
+
+// sortNodes sorts the children of n by their value, keeping the order of equal ones.
+func sortNodes(n *Node) {
+	sort.SliceStable(n.Nodes, func(i, j int) bool {
+		return valueString(n.Nodes[i]) < valueString(n.Nodes[j])
+	})
+	for i, node := range n.Nodes {
+		node.index = i
+	}
+	n.MarkDirty()
+}
+
+// copyTree returns a deep copy of the tree rooted at n, producing the lazy children.
+func copyTree(n *Node) *Node {
+	return copyTreeDepth(n, 0)
+}
+
+// copyTreeDepth is like copyTree, copying the nodes down to the given depth only,
+// where the children of n have depth 1. The lazy children of the deepest copied
+// nodes are not produced. Zero means no limit. The copy keeps the printer options of the tree.
+// A Node reached again from one of its own descendants is copied without its children.
+func copyTreeDepth(n *Node, depth int) *Node {
+	return copyMatching(n, depth, nil)
+}
+
+// copyMatching is like copyTreeDepth, copying only the descendants matched by fn,
+// their ancestors and their subtrees when fn is not nil. The whole tree is searched,
+// but only the nodes kept down to the depth are copied.
+func copyMatching(n *Node, depth int, fn func(*Node) bool) *Node {
+	// found holds the matching nodes and the ones with a matching descendant,
+	// marked from the parents of the traversal, in reverse pre-order
+	var matched, found map[*Node]bool
+	if fn != nil {
+		matched, found = make(map[*Node]bool), make(map[*Node]bool)
+		type visit struct {
+			item, parent *Node
+		}
+		var visits []visit
+		walkNodes(n, func(item *Node, _ int, parent *Node) WalkAction {
+			visits = append(visits, visit{item: item, parent: parent})
+			return WalkContinue
+		})
+		for i := len(visits) - 1; i >= 0; i-- {
+			v := visits[i]
+			if fn(v.item) {
+				matched[v.item] = true
+			}
+			if matched[v.item] || found[v.item] {
+				found[v.item] = true
+				found[v.parent] = true
+			}
+		}
+	}
+	root := &Node{Meta: n.Meta, Value: n.Value, status: n.status, description: n.description}
+	if options := n.root().options; options != nil {
+		copied := *options
+		root.options = &copied
+	}
+	type frame struct {
+		src, dst *Node
+		nodes    []*Node
+		i, depth int
+		// whole is set below a matching Node, whose subtree is kept whole
+		whole bool
+	}
+	newFrame := func(src, dst *Node, d int, whole bool) frame {
+		if depth > 0 && d >= depth {
+			return frame{src: src, dst: dst, depth: d}
+		}
+		return frame{src: src, dst: dst, nodes: src.children(), depth: d, whole: whole}
+	}
+	stack := []frame{newFrame(n, root, 0, fn == nil)}
+	path := map[*Node]bool{n: true}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.nodes) {
+			delete(path, top.src)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.nodes[top.i]
+		top.i++
+		keep := found[node]
+		if path[node] {
+			// copied without its children, it is kept only for its own match
+			keep = matched[node]
+		}
+		if !top.whole && !keep {
+			continue
+		}
+		child := top.dst.newChild(node.Meta, node.Value)
+		child.status = node.status
+		child.description = node.description
+		top.dst.Nodes = append(top.dst.Nodes, child)
+		if !path[node] {
+			path[node] = true
+			stack = append(stack, newFrame(node, child, top.depth+1, top.whole || matched[node]))
+		}
+	}
+	return root
+}
@@ -0,0 +1,55 @@
+package treeprint
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	web := tree.AddBranch("web")
+	web.AddNode("z")
+	web.AddNode("api <v2>")
+	db := tree.AddBranch("db")
+	db.AddBranch("api").AddNode("users")
+	db.AddNode("cache")
+
+	render := func(text string) string {
+		buf := new(strings.Builder)
+		tmpl := template.Must(template.New("").Funcs(TemplateFuncs()).Parse(text))
+		assert.NoError(tmpl.Execute(buf, tree))
+		return buf.String()
+	}
+	assert.Equal(tree.String(), render(`{{treeString .}}`))
+	assert.Equal(`.
+├── db
+│   ├── api
+│   │   └── users
+│   └── cache
+└── web
+    ├── api <v2>
+    └── z
+`, render(`{{. | treeSort | treeString}}`))
+	assert.Equal(`.
+├── web
+│   └── api <v2>
+└── db
+    └── api
+        └── users
+`, render(`{{. | treeFilter "api" | treeString}}`))
+	assert.Equal(".\n├── web\n└── db\n", render(`{{treeMaxDepth 1 .}}`))
+	assert.Equal("[.][└── web][    └── api <v2>]", render(`{{range treeFilter "v2" . | treeLines}}[{{.}}]{{end}}`))
+	assert.Equal(string(tree.Bytes(NewPrinter(WithEdgeStyle(ASCIIEdgeStyle)))), render(`{{treeASCII .}}`))
+	assert.Equal("web", tree.(*Node).Nodes[0].Value, "the tree is left unchanged")
+
+	buf := new(strings.Builder)
+	tmpl := htmltemplate.Must(htmltemplate.New("").Funcs(TemplateFuncs()).Parse(`<pre>{{treeFilter "v2" . | treeString}}</pre>`))
+	assert.NoError(tmpl.Execute(buf, tree))
+	assert.Equal("<pre>.\n└── web\n    └── api &lt;v2&gt;\n</pre>", buf.String())
+}
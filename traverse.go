@@ -0,0 +1,278 @@
+package treeprint
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrSkipChildren can be returned by a WalkE callback to skip the children
+// of the visited Node, it is not returned by WalkE itself.
+var ErrSkipChildren = errors.New("treeprint: skip children")
+
+// DepthVisitor function type for iterating over nodes along with their depth,
+// where the depth of the Node the traversal starts from is 0,
+// the depth of its children is 1 and so on.
+type DepthVisitor func(item *Node, depth int)
+
+// ParentVisitor function type for iterating over nodes along with their depth
+// and the parent they were reached from.
+type ParentVisitor func(item *Node, depth int, parent *Node)
+
+// WalkAction tells Walk how to proceed after visiting a Node.
+type WalkAction int
+
+const (
+	// WalkContinue continues the traversal as usual.
+	WalkContinue WalkAction = iota
+	// WalkSkipChildren skips the children of the visited Node, like filepath.SkipDir.
+	WalkSkipChildren
+	// WalkStop stops the traversal altogether.
+	WalkStop
+)
+
+// WalkFunc function type for Walk, the returned action controls the traversal.
+type WalkFunc func(item *Node) WalkAction
+
+// WalkErrFunc function type for WalkE.
+type WalkErrFunc func(item *Node) error
+
+// VisitPreOrder calls fn for every descendant of n in depth-first pre-order:
+// a Node is visited before its children, and children in their order.
+// The Node itself is not visited.
+func (n *Node) VisitPreOrder(fn NodeVisitor) {
+	walkNodes(n, func(item *Node, _ int, _ *Node) WalkAction {
+		fn(item)
+		return WalkContinue
+	})
+}
+
+// VisitPostOrder calls fn for every descendant of n in depth-first post-order:
+// a Node is visited after all of its children, which makes it suitable
+// for bottom-up aggregation. The Node itself is not visited.
+func (n *Node) VisitPostOrder(fn NodeVisitor) {
+	postOrderWalk(n, func(item *Node, _ int) WalkAction {
+		fn(item)
+		return WalkContinue
+	})
+}
+
+// VisitBFS calls fn for every descendant of n in breadth-first order:
+// all nodes of depth 1 are visited first, then all nodes of depth 2 and so on.
+// The Node itself is not visited.
+func (n *Node) VisitBFS(fn DepthVisitor) {
+	breadthFirst(n, func(item *Node, depth int) WalkAction {
+		fn(item, depth)
+		return WalkContinue
+	})
+}
+
+// VisitWithParent calls fn for every descendant of n in depth-first pre-order,
+// passing the depth of the Node and the Node it was reached from.
+// The parent comes from the traversal itself, so it is correct even if Root is not.
+// The Node itself is not visited.
+func (n *Node) VisitWithParent(fn ParentVisitor) {
+	walkNodes(n, func(item *Node, depth int, parent *Node) WalkAction {
+		fn(item, depth, parent)
+		return WalkContinue
+	})
+}
+
+// Walk calls fn for every descendant of n in depth-first pre-order,
+// skipping the children of a Node when fn returns WalkSkipChildren
+// and stopping when it returns WalkStop. The Node itself is not visited.
+// It reports whether the traversal was stopped.
+func (n *Node) Walk(fn WalkFunc) bool {
+	return walkNodes(n, func(item *Node, _ int, _ *Node) WalkAction {
+		return fn(item)
+	})
+}
+
+// WalkE calls fn for every descendant of n in depth-first pre-order
+// and aborts on the first error, returning it.
+// If fn returns ErrSkipChildren, the children of that Node are skipped instead.
+// The Node itself is not visited.
+func (n *Node) WalkE(fn WalkErrFunc) error {
+	var err error
+	walkNodes(n, func(item *Node, _ int, _ *Node) WalkAction {
+		switch err = fn(item); err {
+		case nil:
+			return WalkContinue
+		case ErrSkipChildren:
+			err = nil
+			return WalkSkipChildren
+		}
+		return WalkStop
+	})
+	return err
+}
+
+// Stream emits the descendants of n in depth-first pre-order on the returned channel.
+// Nodes are produced lazily as the consumer receives them, and the channel is closed
+// when the traversal is complete or ctx is cancelled, whichever happens first.
+// The consumer has to drain the channel or cancel ctx to release the producing goroutine.
+func (n *Node) Stream(ctx context.Context) <-chan *Node {
+	ch := make(chan *Node)
+	go func() {
+		defer close(ch)
+		n.Walk(func(item *Node) WalkAction {
+			select {
+			case ch <- item:
+				return WalkContinue
+			case <-ctx.Done():
+				return WalkStop
+			}
+		})
+	}()
+	return ch
+}
+
+// VisitParallel calls fn for every descendant of n using a pool of workers goroutines.
+// A Node is always visited before its children, but there is no ordering between
+// nodes of independent subtrees, so fn must be safe for concurrent use.
+// It returns once every Node has been visited. The Node itself is not visited.
+func (n *Node) VisitParallel(fn NodeVisitor, workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	var (
+		mu       sync.Mutex
+		cond     = sync.NewCond(&mu)
+		queue    []*branch
+		pending  int
+		wg       sync.WaitGroup
+		expanded = map[*Node]bool{n: true}
+	)
+	for _, node := range n.children() {
+		queue = append(queue, &branch{node: node, depth: 1})
+	}
+	pending = len(queue)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				for len(queue) == 0 && pending > 0 {
+					cond.Wait()
+				}
+				if pending == 0 {
+					mu.Unlock()
+					return
+				}
+				b := queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+				// a Node reached again from one of its own descendants is not expanded again
+				cycle := b.cycles(n, expanded)
+				expanded[b.node] = true
+				mu.Unlock()
+
+				fn(b.node)
+				// children become available only after their parent has been visited
+				var children []*Node
+				if !cycle {
+					children = b.node.children()
+				}
+
+				mu.Lock()
+				for _, node := range children {
+					queue = append(queue, &branch{node: node, depth: b.depth + 1, parent: b})
+				}
+				pending += len(children) - 1
+				cond.Broadcast()
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// VisitLeaves calls fn for every descendant of n that has no children, in pre-order.
+func (n *Node) VisitLeaves(fn NodeVisitor) {
+	n.VisitPreOrder(func(item *Node) {
+		if len(item.children()) == 0 {
+			fn(item)
+		}
+	})
+}
+
+// VisitBranches calls fn for every descendant of n that has children, in pre-order.
+func (n *Node) VisitBranches(fn NodeVisitor) {
+	n.VisitPreOrder(func(item *Node) {
+		if len(item.children()) > 0 {
+			fn(item)
+		}
+	})
+}
+
+// Ancestors returns the ancestors of n by following Root pointers,
+// starting with the parent of n and ending with the root of the tree.
+// The Node itself is not included, so the root of a tree has no ancestors.
+func (n *Node) Ancestors() []*Node {
+	var ancestors []*Node
+	n.VisitAncestors(func(item *Node) {
+		ancestors = append(ancestors, item)
+	})
+	return ancestors
+}
+
+// VisitAncestors calls fn for every ancestor of n in the same order as Ancestors,
+// from the parent of n up to the root of the tree.
+func (n *Node) VisitAncestors(fn NodeVisitor) {
+	if n == nil {
+		return
+	}
+	for node := n.Root; node != nil; node = node.Root {
+		fn(node)
+	}
+}
+
+// NodesAtDepth returns the nodes of the given depth below n from left to right,
+// where n itself has depth 0 and its children have depth 1.
+// Subtrees are not descended past the requested depth.
+func (n *Node) NodesAtDepth(depth int) []*Node {
+	if n == nil {
+		return nil
+	}
+	if depth <= 0 {
+		if depth == 0 {
+			return []*Node{n}
+		}
+		return nil
+	}
+	var nodes []*Node
+	walkNodes(n, func(item *Node, d int, _ *Node) WalkAction {
+		if d < depth {
+			return WalkContinue
+		}
+		nodes = append(nodes, item)
+		return WalkSkipChildren
+	})
+	return nodes
+}
+
+// Levels returns the nodes of the tree rooted at n grouped by depth,
+// Levels()[d] holds the same nodes as NodesAtDepth(d).
+func (n *Node) Levels() [][]*Node {
+	if n == nil {
+		return nil
+	}
+	levels := [][]*Node{{n}}
+	n.VisitBFS(func(item *Node, depth int) {
+		if depth == len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[depth] = append(levels[depth], item)
+	})
+	return levels
+}
+
+// VisitBottomUp calls fn for every descendant of n level by level, starting from the
+// deepest level of the whole tree, so all the descendants of a Node are visited before it.
+// Nodes of the same level are visited from left to right. The Node itself is not visited.
+func (n *Node) VisitBottomUp(fn DepthVisitor) {
+	bottomUp(n, func(item *Node, depth int) WalkAction {
+		fn(item, depth)
+		return WalkContinue
+	})
+}
@@ -0,0 +1,267 @@
+package treeprint
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func traverseTree() Tree {
+	tree := New()
+	one := tree.AddBranch("one")
+	one.AddNode("a").AddNode("b")
+	one.AddBranch("two").AddNode("c")
+	tree.AddNode("three")
+	return tree
+}
+
+func TestVisitPreOrder(t *testing.T) {
+	var visited []Value
+	traverseTree().VisitPreOrder(func(item *Node) {
+		visited = append(visited, item.Value)
+	})
+	assert.Equal(t, []Value{"one", "a", "b", "two", "c", "three"}, visited)
+}
+
+func TestVisitPostOrder(t *testing.T) {
+	var visited []Value
+	traverseTree().VisitPostOrder(func(item *Node) {
+		visited = append(visited, item.Value)
+	})
+	assert.Equal(t, []Value{"a", "b", "c", "two", "one", "three"}, visited)
+}
+
+func TestVisitBFS(t *testing.T) {
+	var visited []Value
+	var depths []int
+	traverseTree().VisitBFS(func(item *Node, depth int) {
+		visited = append(visited, item.Value)
+		depths = append(depths, depth)
+	})
+	assert.Equal(t, []Value{"one", "three", "a", "b", "two", "c"}, visited)
+	assert.Equal(t, []int{1, 1, 2, 2, 2, 3}, depths)
+}
+
+func TestVisitWithParent(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := traverseTree()
+	var visited []Value
+	var depths []int
+	tree.VisitWithParent(func(item *Node, depth int, parent *Node) {
+		visited = append(visited, item.Value)
+		depths = append(depths, depth)
+		assert.Contains(parent.Nodes, item)
+	})
+	assert.Equal([]Value{"one", "a", "b", "two", "c", "three"}, visited)
+	assert.Equal([]int{1, 2, 2, 2, 3, 1}, depths)
+}
+
+func TestWalk(t *testing.T) {
+	assert := assert.New(t)
+
+	var visited []Value
+	stopped := traverseTree().Walk(func(item *Node) WalkAction {
+		visited = append(visited, item.Value)
+		switch item.Value {
+		case "one":
+			return WalkSkipChildren
+		case "three":
+			return WalkStop
+		}
+		return WalkContinue
+	})
+	assert.True(stopped)
+	assert.Equal([]Value{"one", "three"}, visited)
+
+	visited = nil
+	stopped = traverseTree().Walk(func(item *Node) WalkAction {
+		visited = append(visited, item.Value)
+		if item.Value == "two" {
+			return WalkStop
+		}
+		return WalkContinue
+	})
+	assert.True(stopped)
+	assert.Equal([]Value{"one", "a", "b", "two"}, visited)
+
+	stopped = traverseTree().Walk(func(item *Node) WalkAction {
+		return WalkContinue
+	})
+	assert.False(stopped)
+}
+
+func TestWalkE(t *testing.T) {
+	assert := assert.New(t)
+
+	errTwo := errors.New("two")
+	var visited []Value
+	err := traverseTree().WalkE(func(item *Node) error {
+		visited = append(visited, item.Value)
+		switch item.Value {
+		case "a":
+			return ErrSkipChildren
+		case "two":
+			return errTwo
+		}
+		return nil
+	})
+	assert.Equal(errTwo, err)
+	assert.Equal([]Value{"one", "a", "b", "two"}, visited)
+
+	visited = nil
+	err = traverseTree().WalkE(func(item *Node) error {
+		visited = append(visited, item.Value)
+		if item.Value == "one" {
+			return ErrSkipChildren
+		}
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([]Value{"one", "three"}, visited)
+}
+
+func TestStream(t *testing.T) {
+	assert := assert.New(t)
+
+	var visited []Value
+	for item := range traverseTree().Stream(context.Background()) {
+		visited = append(visited, item.Value)
+	}
+	assert.Equal([]Value{"one", "a", "b", "two", "c", "three"}, visited)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := traverseTree().Stream(ctx)
+	assert.Equal("one", (<-ch).Value)
+	cancel()
+	for range ch {
+	}
+}
+
+func TestVisitParallel(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu      sync.Mutex
+		visited = make(map[*Node]bool)
+	)
+	tree := traverseTree()
+	tree.VisitParallel(func(item *Node) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.False(visited[item])
+		if item.Root != tree {
+			assert.True(visited[item.Root], "parent of %v is not visited yet", item.Value)
+		}
+		visited[item] = true
+	}, 4)
+	assert.Len(visited, 6)
+
+	New().VisitParallel(func(item *Node) {
+		t.Fail()
+	}, 0)
+}
+
+func TestVisitLeavesAndBranches(t *testing.T) {
+	assert := assert.New(t)
+
+	var leaves, branches []Value
+	tree := traverseTree()
+	tree.VisitLeaves(func(item *Node) {
+		leaves = append(leaves, item.Value)
+	})
+	tree.VisitBranches(func(item *Node) {
+		branches = append(branches, item.Value)
+	})
+	assert.Equal([]Value{"a", "b", "c", "three"}, leaves)
+	assert.Equal([]Value{"one", "two"}, branches)
+}
+
+func TestAncestors(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := traverseTree()
+	c := tree.(*Node).Nodes[0].Nodes[2].Nodes[0]
+	assert.Equal("c", c.Value)
+
+	var values []Value
+	for _, item := range c.Ancestors() {
+		values = append(values, item.Value)
+	}
+	assert.Equal([]Value{"two", "one", "."}, values)
+	assert.Empty(tree.Ancestors())
+}
+
+func values(nodes []*Node) []Value {
+	vals := make([]Value, 0, len(nodes))
+	for _, node := range nodes {
+		vals = append(vals, node.Value)
+	}
+	return vals
+}
+
+func TestNodesAtDepth(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := traverseTree()
+	assert.Equal([]Value{"."}, values(tree.NodesAtDepth(0)))
+	assert.Equal([]Value{"one", "three"}, values(tree.NodesAtDepth(1)))
+	assert.Equal([]Value{"a", "b", "two"}, values(tree.NodesAtDepth(2)))
+	assert.Equal([]Value{"c"}, values(tree.NodesAtDepth(3)))
+	assert.Empty(tree.NodesAtDepth(4))
+	assert.Empty(tree.NodesAtDepth(-1))
+
+	levels := tree.Levels()
+	assert.Len(levels, 4)
+	for d, level := range levels {
+		assert.Equal(tree.NodesAtDepth(d), level)
+	}
+}
+
+func TestVisitBottomUp(t *testing.T) {
+	var visited []Value
+	var depths []int
+	traverseTree().VisitBottomUp(func(item *Node, depth int) {
+		visited = append(visited, item.Value)
+		depths = append(depths, depth)
+	})
+	assert.Equal(t, []Value{"c", "a", "b", "two", "one", "three"}, visited)
+	assert.Equal(t, []int{3, 2, 2, 2, 1, 1}, depths)
+}
+
+func TestDeepTree(t *testing.T) {
+	assert := assert.New(t)
+
+	// a small stack limit turns any recursion over the depth into a crash
+	defer debug.SetMaxStack(debug.SetMaxStack(1 << 20))
+
+	const depth = 100000
+	tree := New()
+	branch := tree
+	for i := 0; i < depth; i++ {
+		branch = branch.AddBranch(i)
+	}
+
+	var count int
+	tree.VisitAll(func(*Node) { count++ })
+	assert.Equal(depth, count)
+	tree.VisitPostOrder(func(*Node) { count-- })
+	assert.Zero(count)
+	assert.NoError(tree.Validate())
+	tree.Repair()
+	tree.Prune(func(item *Node) bool { return item.Value == depth-1 })
+	assert.Len(tree.NodesAtDepth(depth-1), 1)
+	assert.Empty(tree.NodesAtDepth(depth))
+
+	shallow := New()
+	branch = shallow
+	for i := 0; i < 2000; i++ {
+		branch = branch.AddBranch(i)
+	}
+	assert.Equal(2001, strings.Count(shallow.String(), "\n"))
+}
@@ -0,0 +1,75 @@
+// Package treegraph converts between treeprint trees and gonum graphs, so that
+// graph algorithms can be run on trees and their results shown as trees.
+package treegraph
+
+import (
+	"sort"
+
+	"github.com/ououmania/treeprint"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Node is a graph node standing for a tree Node.
+type Node struct {
+	id   int64
+	Tree *treeprint.Node
+}
+
+// ID implements graph.Node.
+func (n Node) ID() int64 {
+	return n.id
+}
+
+// ToGraph returns the directed graph of the tree, with an edge from every Node
+// to each of its children. The graph nodes are Node values numbered in pre-order,
+// the root having ID 0.
+func ToGraph(t treeprint.Tree) *simple.DirectedGraph {
+	g := simple.NewDirectedGraph()
+	root := t.(*treeprint.Node)
+	ids := map[*treeprint.Node]int64{root: 0}
+	g.AddNode(Node{id: 0, Tree: root})
+	root.VisitWithParent(func(item *treeprint.Node, _ int, parent *treeprint.Node) {
+		id := int64(len(ids))
+		ids[item] = id
+		node := Node{id: id, Tree: item}
+		g.AddNode(node)
+		g.SetEdge(g.NewEdge(g.Node(ids[parent]), node))
+	})
+	return g
+}
+
+// FromGraph returns the breadth-first spanning tree of the graph from the root,
+// following the edges from every node, whose targets are visited by increasing ID.
+// The value of every Node is given by label, or is the graph node ID if label is nil.
+// The nodes not reachable from the root are left out.
+func FromGraph(g graph.Graph, root graph.Node, label func(graph.Node) treeprint.Value) treeprint.Tree {
+	if label == nil {
+		label = func(n graph.Node) treeprint.Value {
+			return n.ID()
+		}
+	}
+	tree := treeprint.NewWithRoot(label(root))
+	type entry struct {
+		node graph.Node
+		tree treeprint.Tree
+	}
+	visited := map[int64]bool{root.ID(): true}
+	queue := []entry{{node: root, tree: tree}}
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		next := graph.NodesOf(g.From(e.node.ID()))
+		sort.Slice(next, func(i, j int) bool {
+			return next[i].ID() < next[j].ID()
+		})
+		for _, n := range next {
+			if visited[n.ID()] {
+				continue
+			}
+			visited[n.ID()] = true
+			queue = append(queue, entry{node: n, tree: e.tree.AddBranch(label(n))})
+		}
+	}
+	return tree
+}
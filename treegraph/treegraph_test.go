@@ -0,0 +1,49 @@
+package treegraph
+
+import (
+	"testing"
+
+	"github.com/ououmania/treeprint"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestToGraph(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := treeprint.New()
+	one := tree.AddBranch("one")
+	one.AddNode("a")
+	one.AddBranch("b").AddNode("c")
+	tree.AddNode("two")
+
+	g := ToGraph(tree)
+	assert.Equal(6, g.Nodes().Len())
+	assert.Equal(5, g.Edges().Len())
+	assert.Equal("one", g.Node(1).(Node).Tree.Value)
+	assert.True(g.HasEdgeFromTo(3, 4))
+
+	// the depth of "c" is the length of the shortest path from the root
+	shortest := path.DijkstraFrom(g.Node(0), g)
+	assert.Equal(3.0, shortest.WeightTo(4))
+
+	assert.Equal(tree.String(), FromGraph(g, g.Node(0), func(n graph.Node) treeprint.Value {
+		return n.(Node).Tree.Value
+	}).String())
+}
+
+func TestFromGraph(t *testing.T) {
+	assert := assert.New(t)
+
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{{1, 2}, {1, 3}, {2, 3}, {3, 4}, {5, 6}} {
+		g.SetEdge(g.NewEdge(simple.Node(e[0]), simple.Node(e[1])))
+	}
+	assert.Equal(`1
+├── 2
+└── 3
+    └── 4
+`, FromGraph(g, g.Node(1), nil).String())
+}
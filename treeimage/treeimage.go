@@ -0,0 +1,176 @@
+// Package treeimage draws treeprint trees into images, for the places where
+// the text output can't be shown as is, such as reports and chat messages.
+//
+// The text is drawn with a fixed-width font and the connectors are drawn as lines,
+// so that they don't depend on the font having the box-drawing characters.
+package treeimage
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/ououmania/treeprint"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Options configures the drawing, the zero value draws black on white with basicfont.Face7x13.
+type Options struct {
+	// Face is the font of the text.
+	Face font.Face
+	// Foreground is the color of the text and the connectors.
+	Foreground color.Color
+	// Background is the color of the image.
+	Background color.Color
+	// Padding is the margin around the tree in pixels.
+	Padding int
+	// IndentSize is the number of characters per tree level, by default the indentation
+	// of the tree plus the link edge, see treeprint.WithIndentSize.
+	IndentSize int
+	// Normalize, if set, is applied to the text of every line before it is measured and drawn,
+	// as in PrinterOptions.Normalize to draw the text as a printer renders it.
+	Normalize func(string) string
+}
+
+func (o *Options) withDefaults(pf treeprint.PrinterOptions) Options {
+	var opts Options
+	if o != nil {
+		opts = *o
+	}
+	if opts.Face == nil {
+		opts.Face = basicfont.Face7x13
+	}
+	if opts.Foreground == nil {
+		opts.Foreground = color.Black
+	}
+	if opts.Background == nil {
+		opts.Background = color.White
+	}
+	if opts.Padding == 0 {
+		opts.Padding = 8
+	}
+	if opts.IndentSize == 0 {
+		opts.IndentSize = pf.Indent() + 1
+	}
+	return opts
+}
+
+// row is a line of the image, either the first line of a Node or an extra line of its value.
+type row struct {
+	text  string
+	depth int
+	// ended tells for every level down to the Node's one whether its last Node is drawn already,
+	// there's nothing left to link to below then
+	ended []bool
+	// edge is set on the first line of a non-root Node
+	edge bool
+}
+
+// Draw draws the tree into a new image.
+func Draw(t treeprint.Tree, opts *Options) *image.RGBA {
+	o := opts.withDefaults(t.Options())
+	rows := layout(t.(*treeprint.Node))
+	if o.Normalize != nil {
+		for i := range rows {
+			rows[i].text = o.Normalize(rows[i].text)
+		}
+	}
+
+	metrics := o.Face.Metrics()
+	lineHeight := metrics.Height.Ceil()
+	adv, _ := o.Face.GlyphAdvance('0')
+	charWidth := adv.Ceil()
+	indent := o.IndentSize * charWidth
+
+	width := 0
+	for _, r := range rows {
+		if w := r.depth*indent + font.MeasureString(o.Face, r.text).Ceil(); w > width {
+			width = w
+		}
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width+2*o.Padding, len(rows)*lineHeight+2*o.Padding))
+	draw.Draw(img, img.Bounds(), image.NewUniform(o.Background), image.Point{}, draw.Src)
+
+	fg := image.NewUniform(o.Foreground)
+	line := func(x0, y0, x1, y1 int) {
+		draw.Draw(img, image.Rect(x0, y0, x1+1, y1+1), fg, image.Point{}, draw.Src)
+	}
+	d := font.Drawer{Dst: img, Src: fg, Face: o.Face}
+	for i, r := range rows {
+		top := o.Padding + i*lineHeight
+		middle := top + lineHeight/2
+		// the connectors of level k are drawn in the middle of the first character of the level
+		levelX := func(k int) int {
+			return o.Padding + k*indent + charWidth/2
+		}
+		for k := 0; k < r.depth; k++ {
+			x := levelX(k)
+			switch {
+			case r.edge && k == r.depth-1:
+				bottom := top + lineHeight - 1
+				if r.ended[k] {
+					bottom = middle
+				}
+				line(x, top, x, bottom)
+				line(x, middle, o.Padding+(k+1)*indent-charWidth/2, middle)
+			case !r.ended[k]:
+				line(x, top, x, top+lineHeight-1)
+			}
+		}
+		d.Dot = fixed.P(o.Padding+r.depth*indent, top+metrics.Ascent.Ceil())
+		d.DrawString(r.text)
+	}
+	return img
+}
+
+// EncodePNG draws the tree and writes it into w as a PNG image.
+func EncodePNG(w io.Writer, t treeprint.Tree, opts *Options) error {
+	if err := png.Encode(w, Draw(t, opts)); err != nil {
+		return fmt.Errorf("treeimage: %w", err)
+	}
+	return nil
+}
+
+// layout returns the rows of the tree, the text of the nodes being formatted
+// as the default printer does.
+func layout(root *treeprint.Node) []row {
+	var rows []row
+	addNode := func(n *treeprint.Node, depth int, ended []bool) {
+		text := fmt.Sprint(n.Value)
+		if n.Meta != nil {
+			text = fmt.Sprintf("[%v]  %s", n.Meta, text)
+		}
+		// the extra lines of the value are drawn under the text, without an edge
+		for i, l := range strings.Split(text, "\n") {
+			rows = append(rows, row{text: l, depth: depth, ended: ended, edge: depth > 0 && i == 0})
+		}
+	}
+	addNode(root, 0, nil)
+	type frame struct {
+		nodes []*treeprint.Node
+		i     int
+		ended []bool
+	}
+	stack := []frame{{nodes: root.NodesAtDepth(1)}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.nodes) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.nodes[top.i]
+		top.i++
+		ended := append(top.ended[:len(top.ended):len(top.ended)], top.i == len(top.nodes))
+		addNode(node, len(ended), ended)
+		if children := node.NodesAtDepth(1); len(children) > 0 {
+			stack = append(stack, frame{nodes: children, ended: ended})
+		}
+	}
+	return rows
+}
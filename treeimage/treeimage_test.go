@@ -0,0 +1,70 @@
+package treeimage
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/ououmania/treeprint"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestDraw(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := treeprint.New()
+	one := tree.AddMetaBranch("m", "one")
+	one.AddNode("a\nb")
+	tree.AddNode("two")
+
+	img := Draw(tree, nil)
+	// 4 nodes and an extra value line of 13 pixels, with 8 pixels of padding
+	assert.Equal(5*13+16, img.Bounds().Dy())
+	// "[m]  one" is the widest line, at depth 1 with 4 characters of 7 pixels per level
+	assert.Equal(4*7+8*7+16, img.Bounds().Dx())
+
+	black := color.RGBA{A: 0xff}
+	white := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	assert.Equal(white, img.RGBAAt(0, 0))
+	// the link of the top-level nodes goes from the root down to the edge of "two"
+	x := 8 + 7/2
+	for y := 8 + 13; y <= 8+4*13+13/2; y++ {
+		assert.Equal(black, img.RGBAAt(x, y), y)
+	}
+	assert.Equal(white, img.RGBAAt(x, 8+4*13+13/2+1))
+	// the edge of "two"
+	assert.Equal(black, img.RGBAAt(x+10, 8+4*13+13/2))
+
+	buf := new(bytes.Buffer)
+	assert.NoError(EncodePNG(buf, tree, &Options{Background: color.Transparent}))
+	decoded, err := png.Decode(buf)
+	if assert.NoError(err) {
+		assert.Equal(img.Bounds(), decoded.Bounds())
+		_, _, _, a := decoded.At(0, 0).RGBA()
+		assert.Zero(a)
+	}
+}
+
+func TestDrawIndent(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := treeprint.New(treeprint.WithIndentSize(1))
+	tree.AddNode("one")
+	// "one" at depth 1 with 2 characters of 7 pixels per level
+	assert.Equal(2*7+3*7+16, Draw(tree, nil).Bounds().Dx())
+	assert.Equal(5*7+3*7+16, Draw(tree, &Options{IndentSize: 5}).Bounds().Dx())
+}
+
+func TestDrawNormalize(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := treeprint.New()
+	tree.AddNode("cafe\u0301")
+
+	plain := Draw(tree, nil)
+	normalized := Draw(tree, &Options{Normalize: treeprint.NewPrinter(treeprint.WithNormalization(norm.NFC)).Normalize})
+	// the combining accent is merged into a single character
+	assert.Equal(plain.Bounds().Dx()-7, normalized.Bounds().Dx())
+}
@@ -3,10 +3,18 @@ package treeprint
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"html"
 	"io"
+	"io/fs"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 // Value defines any value
@@ -26,6 +34,61 @@ type PrintValuePrint func(Value, io.Writer)
 type PrinterOptions struct {
 	metaFunc   PrintMetaFunc
 	valuePrint PrintValuePrint
+	maxDepth   int
+	maxNodes   int
+	valueCache *ValueCache
+
+	maxBytes     int
+	strictLimits bool
+
+	edges EdgeStyle
+	fence *fence
+
+	controlChars ControlChars
+	keepColors   bool
+
+	normalize bool
+	normForm  norm.Form
+
+	indent    int
+	indentSet bool
+
+	measure MeasureFunc
+
+	renderHook RenderHook
+
+	checkboxes     bool
+	checkboxColors bool
+
+	less LessFunc
+
+	highlight         Matcher
+	highlightStyle    Style
+	collapseUnmatched bool
+
+	annotations *Annotations
+
+	styleRules []StyleRule
+
+	hashFunc HashFunc
+
+	levelMetaFuncs []PrintMetaFunc
+
+	truncateWidth  int
+	ellipsis       string
+	truncationHook TruncationHook
+
+	ancestors   bool
+	ancestorSep string
+
+	lineEnding  string
+	transformer transform.Transformer
+
+	descriptionStyle Style
+
+	messages MessageFunc
+
+	levelMarkers bool
 }
 
 type Option func(*PrinterOptions)
@@ -42,6 +105,34 @@ func WithValuePrint(f PrintValuePrint) Option {
 	}
 }
 
+// WithMaxDepth limits the rendering to the given depth, where the children
+// of the rendered Node have depth 1. Deeper nodes are neither rendered nor,
+// if lazy, produced. Zero means no limit. See also WithStrictLimits.
+func WithMaxDepth(depth int) Option {
+	return func(p *PrinterOptions) {
+		p.maxDepth = depth
+	}
+}
+
+// WithMaxNodes stops the rendering after the given number of nodes below the rendered one,
+// and ends the output with a line telling how many nodes were omitted.
+// Lazy children are not produced for the count. Zero means no limit.
+// PrintToParallel renders sequentially when the number of nodes is limited,
+// and IncrementalPrinter doesn't support the limit.
+func WithMaxNodes(n int) Option {
+	return func(p *PrinterOptions) {
+		p.maxNodes = n
+	}
+}
+
+// WithEdgeStyle draws the tree with the given edges instead of EdgeTypeLink,
+// EdgeTypeMid and EdgeTypeEnd. The link edge is expected to be one column wide.
+func WithEdgeStyle(style EdgeStyle) Option {
+	return func(p *PrinterOptions) {
+		p.edges = style
+	}
+}
+
 func NewPrinter(options ...Option) PrinterOptions {
 	p := PrinterOptions{
 		metaFunc:   defaultPrintMeta,
@@ -56,16 +147,28 @@ func NewPrinter(options ...Option) PrinterOptions {
 }
 
 func (p PrinterOptions) printNode(n *Node, w io.Writer) {
+	if p.checkboxes {
+		io.WriteString(w, n.status.checkbox(p.checkboxColors))
+	}
 	if n.Meta != nil {
-		p.printMeta(n.Meta, w)
+		p.printMeta(n.Meta, 0, w)
 	}
 	p.printValue(n.Value, w)
+	p.printDescription(n, w)
+}
+
+// FormatNode returns the meta and the value of the Node as printed on its line, without the edges.
+func (p PrinterOptions) FormatNode(n *Node) string {
+	var b strings.Builder
+	p.printNode(n, &b)
+	return b.String()
 }
 
-func (p PrinterOptions) printMeta(m MetaValue, w io.Writer) {
-	if p.metaFunc != nil {
-		p.metaFunc(m, w)
-		fmt.Fprintf(w, "  ")
+// printMeta prints the meta value of a Node of the depth followed by the separator, if shown.
+func (p PrinterOptions) printMeta(m MetaValue, depth int, w io.Writer) {
+	if fn := p.metaFuncAt(depth); fn != nil {
+		fn(m, w)
+		io.WriteString(w, "  ")
 	}
 }
 
@@ -76,10 +179,25 @@ func (p PrinterOptions) printValue(v Value, w io.Writer) {
 }
 
 func defaultPrintMeta(m MetaValue, w io.Writer) {
+	s, ok := formatMeta(m)
+	if !ok {
+		s, ok = m.(string)
+	}
+	if ok {
+		// skip fmt for the most common case
+		io.WriteString(w, "[")
+		io.WriteString(w, s)
+		io.WriteString(w, "]")
+		return
+	}
 	fmt.Fprintf(w, "[%v]", m)
 }
 
 func defaultPrintValue(v Value, w io.Writer) {
+	if s, ok := v.(string); ok {
+		io.WriteString(w, s)
+		return
+	}
 	fmt.Fprintf(w, "%v", v)
 }
 
@@ -93,283 +211,1143 @@ type Tree interface {
 	AddBranch(v Value) Tree
 	// AddMetaBranch adds a new branch Node (a level deeper) with meta value provided.
 	AddMetaBranch(meta MetaValue, v Value) Tree
+	// AddPlaceholder adds a Node standing for unloaded children, see Expand.
+	AddPlaceholder(text string, load ChildrenFunc) Tree
+	// IsPlaceholder reports whether the Node is a placeholder.
+	IsPlaceholder() bool
+	// Expand replaces a placeholder Node with the nodes it loads.
+	Expand() []*Node
 	// Branch converts a leaf-Node to a branch-Node,
 	// applying this on a branch-Node does no effect.
 	Branch() Tree
+	// Detach removes the Node from its parent, making it the root of its own tree.
+	Detach() Tree
+	// Promote moves the Node up a level, right after its parent.
+	Promote() Tree
 	// FindByMeta finds a Node whose meta value matches the provided one by reflect.DeepEqual,
-	// returns nil if not found.
+	// returns nil if not found. Use FindNodeByMeta to chain calls on the result.
 	FindByMeta(meta MetaValue) Tree
 	// FindByValue finds a Node whose value matches the provided one by reflect.DeepEqual,
-	// returns nil if not found.
+	// returns nil if not found. Use FindNodeByValue to chain calls on the result.
 	FindByValue(value Value) Tree
-	//  returns the last Node of a tree
+	// FindNodeByMeta is like FindByMeta, but returns a nil *Node if not found.
+	FindNodeByMeta(meta MetaValue) *Node
+	// FindNodeByValue is like FindByValue, but returns a nil *Node if not found.
+	FindNodeByValue(value Value) *Node
+	// Find finds the first Node matched by m, returns nil if not found.
+	Find(m Matcher) Tree
+	// FindByPath follows the path of values from the Node down, returns nil if not found.
+	FindByPath(path ...Value) Tree
+	// Paths returns the paths to all the descendants, or to the leaves only, joined by sep.
+	Paths(sep string, leavesOnly bool) []string
+	// WritePaths writes the paths returned by Paths, one per line.
+	WritePaths(w io.Writer, sep string, leavesOnly bool) error
+	// Observe registers callbacks notified of the changes made to the tree.
+	Observe(o Observer) (cancel func())
+	// Hash returns a digest of the structure, values and metas of the tree or subtree.
+	Hash() []byte
+	// Extract returns a new tree of the descendants matched by fn and their ancestors.
+	Extract(fn ExtractFunc) Tree
+	// Query returns the nodes selected by a path-based query, see CompileQuery.
+	Query(expr string) ([]Tree, error)
+	// AddTree adds the root of another tree as a child of the Node.
+	AddTree(t Tree) Tree
+	// MoveTo moves the Node with its subtree under parent.
+	MoveTo(parent Tree) Tree
+	// AddNodeE is like AddNode, but fails instead of adding a nil value.
+	AddNodeE(v Value) (Tree, error)
+	// AddMetaNodeE is like AddMetaNode, but fails instead of adding a nil value.
+	AddMetaNodeE(meta MetaValue, v Value) (Tree, error)
+	// AddBranchE is like AddBranch, but fails instead of adding a nil value.
+	AddBranchE(v Value) (Tree, error)
+	// AddMetaBranchE is like AddMetaBranch, but fails instead of adding a nil value.
+	AddMetaBranchE(meta MetaValue, v Value) (Tree, error)
+	// FindByMetaE is like FindByMeta, but fails instead of returning nil.
+	FindByMetaE(meta MetaValue) (Tree, error)
+	// FindByValueE is like FindByValue, but fails instead of returning nil.
+	FindByValueE(value Value) (Tree, error)
+	// FindByPathE is like FindByPath, but fails instead of returning nil.
+	FindByPathE(path ...Value) (Tree, error)
+	// AddTreeE is like AddTree, but fails instead of creating a cycle or sharing a Node.
+	AddTreeE(t Tree) (Tree, error)
+	// MoveToE is like MoveTo, but fails instead of creating a cycle.
+	MoveToE(parent Tree) (Tree, error)
+	// FindLastNode returns the last child of the Node, or nil if there are none.
+	// Use LastNode to chain calls on the result.
 	FindLastNode() Tree
+	// LastNode is like FindLastNode, but returns a nil *Node if there are no children.
+	LastNode() *Node
 	// String renders the tree or subtree as a string.
 	Print(PrinterOptions) string
 	// String renders the tree or subtree as a string.
 	String() string
+	// PrintTo renders the tree or subtree straight into w.
+	PrintTo(w io.Writer, f PrinterOptions) (int64, error)
+	// WriteTo renders the tree or subtree into w with the default printer options.
+	WriteTo(w io.Writer) (int64, error)
 	// Bytes renders the tree or subtree as byteslice.
 	Bytes(PrinterOptions) []byte
+	// EstimateSize estimates the rendered size in bytes without running the print funcs.
+	EstimateSize(f PrinterOptions) int64
+	// PrintToParallel renders the top-level subtrees concurrently and writes them into w in order.
+	PrintToParallel(w io.Writer, f PrinterOptions, workers int) (int64, error)
+	// RenderLines renders only the output lines in the range [from, to).
+	RenderLines(f PrinterOptions, from, to int) []string
+	// AppendBytes renders the tree or subtree appending it to dst.
+	AppendBytes(dst []byte, f PrinterOptions) []byte
+	// Render renders the tree or subtree into w until ctx is done.
+	Render(ctx context.Context, w io.Writer, options ...Option) error
+	// PrintLines renders the tree or subtree calling fn once per line.
+	PrintLines(f PrinterOptions, fn LineFunc) error
+	// Format renders the tree or subtree, it implements fmt.Formatter.
+	Format(s fmt.State, verb rune)
+	// MarshalText renders the tree or subtree, it implements encoding.TextMarshaler.
+	MarshalText() ([]byte, error)
+	// MarshalJSON encodes the tree or subtree as nested objects, it implements json.Marshaler.
+	MarshalJSON() ([]byte, error)
+	// UnmarshalJSON replaces the Node content with a decoded tree, it implements json.Unmarshaler.
+	UnmarshalJSON(b []byte) error
+	// UnmarshalText replaces the Node content with a parsed tree, it implements encoding.TextUnmarshaler.
+	UnmarshalText(text []byte) error
 
 	SetValue(value Value)
 	SetMetaValue(meta MetaValue)
 
 	// VisitAll iterates over the tree, branches and nodes.
 	// If need to iterate over the whole tree, use the root Node.
-	// Note this method uses a breadth-first approach.
+	// Note this method uses a depth-first pre-order approach, same as VisitPreOrder.
 	VisitAll(fn NodeVisitor)
+	// VisitPreOrder visits every descendant, each Node before its children.
+	VisitPreOrder(fn NodeVisitor)
+	// VisitPostOrder visits every descendant, each Node after its children.
+	VisitPostOrder(fn NodeVisitor)
+	// VisitBFS visits every descendant level by level, reporting the depth of each Node.
+	VisitBFS(fn DepthVisitor)
+	// VisitWithParent visits every descendant in pre-order, reporting its depth and parent.
+	VisitWithParent(fn ParentVisitor)
+	// Walk visits every descendant in pre-order, letting fn skip subtrees or stop early.
+	Walk(fn WalkFunc) bool
+	// WalkE visits every descendant in pre-order and aborts on the first error returned by fn.
+	WalkE(fn WalkErrFunc) error
+	// Stream emits every descendant in pre-order on a channel until ctx is cancelled.
+	Stream(ctx context.Context) <-chan *Node
+	// VisitParallel visits every descendant using a pool of workers, parents before their children.
+	VisitParallel(fn NodeVisitor, workers int)
+	// VisitLeaves visits every descendant without children in pre-order.
+	VisitLeaves(fn NodeVisitor)
+	// VisitBranches visits every descendant with children in pre-order.
+	VisitBranches(fn NodeVisitor)
+	// Ancestors returns the ancestors from the parent up to the root of the tree.
+	Ancestors() []*Node
+	// VisitAncestors visits the ancestors from the parent up to the root of the tree.
+	VisitAncestors(fn NodeVisitor)
+	// VisitBottomUp visits every descendant level by level, starting from the deepest one.
+	VisitBottomUp(fn DepthVisitor)
+	// NodesAtDepth returns the nodes of the given depth, the Node itself has depth 0.
+	NodesAtDepth(depth int) []*Node
+	// Levels returns all the nodes grouped by depth.
+	Levels() [][]*Node
+	// WalkOrder visits every descendant in the given order, letting fn skip subtrees or stop early.
+	WalkOrder(order TraversalOrder, fn WalkFunc) bool
+	// WalkOrderContext is like WalkOrder, but stops once ctx is done and returns its error.
+	WalkOrderContext(ctx context.Context, order TraversalOrder, fn WalkFunc) error
+	// WalkContext is like Walk, but stops once ctx is done and returns its error.
+	WalkContext(ctx context.Context, fn WalkFunc) error
+	// VisitAllContext is like VisitAll, but stops once ctx is done and returns its error.
+	VisitAllContext(ctx context.Context, fn NodeVisitor) error
 
 	Prune(fn PruneFunc)
 
 	ChildCount() int
+
+	// Validate checks that the tree has no cycles, no nil children and
+	// that every child's Root points to its actual parent.
+	Validate() error
+	// Repair fixes parent pointers and drops nil or repeated children,
+	// so that Validate passes afterwards.
+	Repair()
+
+	// Intern replaces the string values and metas with the shared copies of the Interner.
+	Intern(in *Interner)
+
+	// Freeze returns a read-only view of the tree or subtree.
+	Freeze() ImmutableTree
+	// FS returns a read-only file system view of the tree or subtree.
+	FS() fs.FS
+
+	// SetChildrenFunc sets a callback producing the children on demand.
+	SetChildrenFunc(fn ChildrenFunc)
+	// MarkDirty invalidates cached renders of the Node and its ancestors.
+	MarkDirty()
+	// Index returns the position of the Node among its siblings, or -1 for a root.
+	Index() int
+	// IsLast reports whether the Node is the last one of its siblings.
+	IsLast() bool
+	// Options returns the printer options the tree was created with.
+	Options() PrinterOptions
+	// Width returns the width of the widest rendered line.
+	Width(f PrinterOptions) int
+	// Measure returns the number of lines and the widths of the output without rendering it.
+	Measure(f PrinterOptions) Metrics
+	// Aggregate sums the weights of the leaves into the metas of the branches, like du.
+	Aggregate(weight WeightFunc, format FormatFunc) float64
+	// PrintLineInfo renders the tree or subtree, reporting where the line of every Node is.
+	PrintLineInfo(w io.Writer, f PrinterOptions) ([]LineInfo, error)
+	// PrintColumns renders the tree or subtree followed by aligned columns of cells.
+	PrintColumns(w io.Writer, f PrinterOptions, columns ColumnsFunc, headers ...string) (int64, error)
+	// SortChildren sorts the children of every Node of the tree or subtree.
+	SortChildren(less LessFunc)
+	// SetStatus sets the task status rendered by WithCheckboxes.
+	SetStatus(s Status)
+	// Status returns the task status of the Node.
+	Status() Status
+	// SetDescription sets the secondary text rendered under the value.
+	SetDescription(d string)
+	// Description returns the secondary text of the Node.
+	Description() string
 }
 
+// Node is an element of a tree, it implements Tree.
+// A nil *Node behaves as an empty tree: adding to it does nothing, searching it finds nothing,
+// it renders as an empty output and the error-returning methods fail with ErrNilNode.
+// The Find methods returning a Tree give an untyped nil when nothing is found, so their
+// results have to be checked before being used; FindNodeByValue, FindNodeByMeta and
+// LastNode give a nil *Node instead, which can be chained:
+//
+//	tree.FindNodeByValue("x").AddNode("y") // does nothing if there is no "x"
 type Node struct {
 	Root  *Node
 	Meta  MetaValue
 	Value Value
 	Nodes []*Node
+
+	// lazy produces the lazy children, see SetChildrenFunc.
+	lazy *lazyChildren
+	// rev is bumped whenever the subtree changes, see MarkDirty.
+	rev uint64
+	// cached is set while a render of the Node is cached by an IncrementalPrinter.
+	cached bool
+	// arena allocates the children, see NewWithArena.
+	arena *NodeArena
+	// index caches the position of the Node among its siblings, see Index.
+	index int
+	// formatted caches the formatted meta and value, see ValueCache.
+	formatted *cachedValue
+	// options are the printer options of the tree, set on its root, see Options.
+	options *PrinterOptions
+	// status is the task status of the Node, see WithCheckboxes.
+	status Status
+	// description is the secondary text of the Node, see SetDescription.
+	description string
+	// observers are notified of the changes to the tree, set on its root, see Observe.
+	observers []*Observer
+	// watched is set on the nodes of the trees observed at some point, the others
+	// don't look up their root for observers on every change.
+	watched bool
+}
+
+// Index returns the position of the Node among the children of its Root, or -1 for a root.
+// The position is cached when the Node is added and verified on every call,
+// so it's constant time unless the children were rearranged since.
+func (n *Node) Index() int {
+	if n == nil {
+		return -1
+	}
+	if n.Root == nil {
+		return -1
+	}
+	i := n.Root.indexOf(n)
+	if i >= 0 && i != n.index {
+		n.index = i
+	}
+	return i
+}
+
+// IsLast reports whether the Node is the last child of its Root.
+// A root is considered the last one, as there's nothing below it to link to.
+func (n *Node) IsLast() bool {
+	if n == nil {
+		return true
+	}
+	if n.Root == nil {
+		return true
+	}
+	return n.Index() == len(n.Root.Nodes)-1
+}
+
+// indexOf returns the position of child among the children of n, or -1 if it's not one of them.
+// The cached index is tried first, but never updated, so that nodes shared
+// between trees (see Cow) are not written to.
+func (n *Node) indexOf(child *Node) int {
+	if i := child.index; i >= 0 && i < len(n.Nodes) && n.Nodes[i] == child {
+		return i
+	}
+	for i, node := range n.Nodes {
+		if node == child {
+			return i
+		}
+	}
+	return -1
+}
+
+// MarkDirty records that the Node has changed, so that the cached renders
+// of its subtree and of all its ancestors are invalidated (see IncrementalPrinter).
+// The mutating methods call it on their own, it's only needed after modifying
+// the Node fields directly.
+func (n *Node) MarkDirty() {
+	for node := n; node != nil; node = node.Root {
+		node.rev++
+		// nothing above a Node without a cached render can be cached,
+		// either it was invalidated already or it has never been rendered
+		if !node.cached {
+			break
+		}
+		node.cached = false
+	}
+}
+
+// ChildrenFunc function type for producing the children of a Node on demand.
+type ChildrenFunc func() []*Node
+
+// SetChildrenFunc makes fn the source of the Node children that are not materialized yet.
+// It is invoked once, only when the children are actually needed by a traversal
+// or by rendering within the depth limit, and its result is appended to Nodes.
+func (n *Node) SetChildrenFunc(fn ChildrenFunc) {
+	if n == nil {
+		return
+	}
+	n.lazy = nil
+	if fn != nil {
+		n.lazy = &lazyChildren{fn: fn}
+	}
+	n.MarkDirty()
+}
+
+// lazyChildren produces the lazy children of a Node exactly once, even when
+// concurrent renders of a tree shared by Cow views reach the Node together.
+type lazyChildren struct {
+	once sync.Once
+	fn   ChildrenFunc
+	done uint32
+}
+
+// pending reports whether the children are not produced yet.
+func (l *lazyChildren) pending() bool {
+	return l != nil && atomic.LoadUint32(&l.done) == 0
+}
+
+// children returns the children of the Node, resolving the lazy ones first.
+func (n *Node) children() []*Node {
+	if n == nil {
+		return nil
+	}
+	if l := n.lazy; l != nil {
+		l.once.Do(func() {
+			for _, node := range l.fn() {
+				n.adopt(node)
+				node.Root = n
+				node.index = len(n.Nodes)
+				n.Nodes = append(n.Nodes, node)
+			}
+			l.fn = nil
+			atomic.StoreUint32(&l.done, 1)
+		})
+	}
+	return n.Nodes
+}
+
+// materialized returns the children of the Node without producing the lazy ones,
+// nil while they are pending, as another render may be producing them meanwhile.
+func (n *Node) materialized() []*Node {
+	if n.lazy.pending() {
+		return nil
+	}
+	return n.Nodes
 }
 
 func (n *Node) FindLastNode() Tree {
-	ns := n.Nodes
-	if len(ns) == 0 {
+	if node := n.LastNode(); node != nil {
+		return node
+	}
+	return nil
+}
+
+// LastNode returns the last child of the Node, or a nil *Node if there are none.
+func (n *Node) LastNode() *Node {
+	if n == nil || len(n.Nodes) == 0 {
 		return nil
 	}
-	return ns[len(ns)-1]
+	return n.Nodes[len(n.Nodes)-1]
 }
 
 func (n *Node) AddNode(v Value) Tree {
-	n.Nodes = append(n.Nodes, &Node{
-		Root:  n,
-		Value: v,
-	})
+	if n == nil {
+		return n
+	}
+	child := n.newChild(nil, v)
+	n.Nodes = append(n.Nodes, child)
+	n.MarkDirty()
+	n.emitAdd(child)
 	return n
 }
 
 func (n *Node) AddMetaNode(meta MetaValue, v Value) Tree {
-	n.Nodes = append(n.Nodes, &Node{
-		Root:  n,
-		Meta:  meta,
-		Value: v,
-	})
+	if n == nil {
+		return n
+	}
+	child := n.newChild(meta, v)
+	n.Nodes = append(n.Nodes, child)
+	n.MarkDirty()
+	n.emitAdd(child)
 	return n
 }
 
 func (n *Node) AddBranch(v Value) Tree {
-	branch := &Node{
-		Root:  n,
-		Value: v,
+	if n == nil {
+		return n
 	}
+	branch := n.newChild(nil, v)
 	n.Nodes = append(n.Nodes, branch)
+	n.MarkDirty()
+	n.emitAdd(branch)
 	return branch
 }
 
 func (n *Node) AddMetaBranch(meta MetaValue, v Value) Tree {
-	branch := &Node{
-		Root:  n,
-		Meta:  meta,
-		Value: v,
+	if n == nil {
+		return n
 	}
+	branch := n.newChild(meta, v)
 	n.Nodes = append(n.Nodes, branch)
+	n.MarkDirty()
+	n.emitAdd(branch)
 	return branch
 }
 
+// Branch returns the Node itself: any Node can be given children, so there is nothing
+// to convert. It used to detach the Node from its parent while leaving it among
+// the parent's children, use Detach to take a subtree out of its tree.
 func (n *Node) Branch() Tree {
-	n.Root = nil
+	return n
+}
+
+// Detach removes the Node from the children of its parent and returns it as the root
+// of its own tree, which renders with the Node on the first line.
+// Detaching a root does nothing.
+func (n *Node) Detach() Tree {
+	if n == nil {
+		return n
+	}
+	n.detach()
+	return n
+}
+
+// Promote moves the Node with its subtree up a level, right after its parent among
+// the children of its grandparent, and returns it. The root and its children can't be promoted,
+// it does nothing for them.
+func (n *Node) Promote() Tree {
+	if n == nil || n.Root == nil || n.Root.Root == nil {
+		return n
+	}
+	parent := n.Root
+	grandparent := parent.Root
+	n.detach()
+	i := grandparent.indexOf(parent) + 1
+	grandparent.Nodes = append(grandparent.Nodes, nil)
+	copy(grandparent.Nodes[i+1:], grandparent.Nodes[i:])
+	grandparent.Nodes[i] = n
+	for j := i; j < len(grandparent.Nodes); j++ {
+		grandparent.Nodes[j].index = j
+	}
+	n.Root = grandparent
+	grandparent.MarkDirty()
+	grandparent.emitAdd(n)
 	return n
 }
 
 func (n *Node) FindByMeta(meta MetaValue) Tree {
-	for _, node := range n.Nodes {
-		if reflect.DeepEqual(node.Meta, meta) {
-			return node
-		}
-		if v := node.FindByMeta(meta); v != nil {
-			return v
-		}
+	if node := n.FindNodeByMeta(meta); node != nil {
+		return node
 	}
 	return nil
 }
 
 func (n *Node) FindByValue(value Value) Tree {
-	for _, node := range n.Nodes {
-		if reflect.DeepEqual(node.Value, value) {
-			return node
-		}
-		if v := node.FindByMeta(value); v != nil {
-			return v
-		}
+	if node := n.FindNodeByValue(value); node != nil {
+		return node
 	}
 	return nil
 }
 
+// FindNodeByMeta is like FindByMeta, but returns a nil *Node if not found,
+// which behaves as an empty tree so calls can be chained on it.
+func (n *Node) FindNodeByMeta(meta MetaValue) *Node {
+	return n.find(func(node *Node) bool {
+		return reflect.DeepEqual(node.Meta, meta)
+	})
+}
+
+// FindNodeByValue is like FindByValue, but returns a nil *Node if not found,
+// which behaves as an empty tree so calls can be chained on it.
+func (n *Node) FindNodeByValue(value Value) *Node {
+	return n.find(func(node *Node) bool {
+		return reflect.DeepEqual(node.Value, value)
+	})
+}
+
 func (n *Node) Bytes(f PrinterOptions) []byte {
-	buf := new(bytes.Buffer)
+	if n == nil {
+		return nil
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	buf.Grow(int(n.EstimateSize(f)))
+	// writing into a bytes.Buffer never fails
+	_, _ = n.PrintTo(buf, f)
+	return append([]byte(nil), buf.Bytes()...)
+}
+
+// AppendBytes renders the tree or subtree appending it to dst, and returns the extended slice.
+// Passing the result of a previous call truncated to zero length reuses its memory.
+func (n *Node) AppendBytes(dst []byte, f PrinterOptions) []byte {
+	w := appendWriter{b: dst}
+	_, _ = n.PrintTo(&w, f)
+	return w.b
+}
+
+// PrintTo renders the tree or subtree straight into w using the given printer options,
+// without building the whole output in memory first.
+// It returns the number of bytes written and the first write error encountered.
+func (n *Node) PrintTo(w io.Writer, f PrinterOptions) (int64, error) {
+	if n == nil {
+		return 0, nil
+	}
+	p := newPrinter(w, f)
+	defer p.release()
+	n.render(p)
+	return p.n, p.err
+}
+
+// Render renders the tree or subtree into w with the printer options of the tree,
+// see Options, changed by the given ones. The context is checked every
+// few nodes, once it is done rendering stops and its error is returned,
+// so the output written so far is incomplete.
+// Otherwise the first write error encountered is returned.
+func (n *Node) Render(ctx context.Context, w io.Writer, options ...Option) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if n == nil {
+		return nil
+	}
+	f := n.Options()
+	for _, opt := range options {
+		opt(&f)
+	}
+	p := newPrinter(w, f)
+	defer p.release()
+	p.ctx = ctx
+	n.render(p)
+	return p.err
+}
+
+// RenderLines renders only the output lines from the line from up to but not including the line to,
+// counted from 0 for the first line of the output. Rendering stops right after the last requested line,
+// which lets pagers and TUIs window into huge trees without rendering all of them.
+// The lines are returned without the line breaks.
+func (n *Node) RenderLines(f PrinterOptions, from, to int) []string {
+	if n == nil {
+		return nil
+	}
+	if from < 0 {
+		from = 0
+	}
+	if to <= from {
+		return nil
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	p := newPrinter(buf, f)
+	defer p.release()
+	p.windowed, p.from, p.to = true, from, to
+	n.render(p)
+	if buf.Len() == 0 {
+		return nil
+	}
+	eol := f.lineEnd()
+	return strings.Split(strings.TrimSuffix(buf.String(), eol), eol)
+}
+
+// render renders the tree or subtree rooted at n with the printer.
+func (n *Node) render(p *printer) {
 	level := 0
-	var levelsEnded []int
-	p := printer{
-		Writer: buf,
-		pf:     f,
+	p.openFence()
+	defer p.closeFence()
+	p.writeAncestors(n)
+	n.renderHeader(p)
+	if p.pf.maxDepth <= 0 || p.pf.maxDepth > level {
+		if nodes := p.pf.children(n); len(nodes) > 0 {
+			printNodes(p, level, n, nodes)
+		}
 	}
+}
+
+// renderHeader renders the line of the Node the rendering starts from.
+func (n *Node) renderHeader(p *printer) {
+	level := 0
 	if n.Root == nil {
-		f.printNode(n, buf)
-		buf.WriteByte('\n')
-	} else {
-		edge := EdgeTypeMid
-		if len(n.Nodes) == 0 {
-			edge = EdgeTypeEnd
-			levelsEnded = append(levelsEnded, level)
+		// the line is written at once, so the byte limit doesn't cut it
+		p.startLine(n, 0)
+		line := p.appendCheckbox(p.line[:0], n)
+		if b, ok := p.hook(n, 0, true); ok {
+			line = append(line, b...)
+		} else {
+			meta, value, _ := p.format(n, 0)
+			meta, value = p.highlighted(n, meta, value)
+			meta, value = p.annotated(n, meta, value)
+			meta, value = p.styled(n, 0, meta, value)
+			line = append(line, meta...)
+			line = append(line, value...)
 		}
-		printValues(&p, 0, levelsEnded, edge, n)
-	}
-	if len(n.Nodes) > 0 {
-		printNodes(&p, level, levelsEnded, n.Nodes)
+		line = p.appendDescription(line, -1, n)
+		p.line = append(line, '\n')
+		p.Write(p.line)
+		p.endLine()
+	} else {
+		p.setEnded(level, len(n.Nodes) == 0)
+		printValues(p, level, len(n.Nodes) == 0, n)
 	}
-	return buf.Bytes()
+}
+
+// WriteTo implements io.WriterTo, rendering the tree or subtree into w
+// with the default printer options, same as String.
+func (n *Node) WriteTo(w io.Writer) (int64, error) {
+	return n.PrintTo(w, n.Options())
 }
 
 func (n *Node) Print(f PrinterOptions) string {
 	return strings.Trim(string(n.Bytes(f)), " \n")
 }
 
+// String renders the tree or subtree with its printer options, see Options.
+// The zero PrinterOptions print neither the metas nor the values, only the edges.
 func (n *Node) String() string {
-	return string(n.Bytes(PrinterOptions{}))
+	return string(n.Bytes(n.Options()))
+}
+
+// Format implements fmt.Formatter. The %v verb renders the tree or subtree without
+// the metas, %+v and %s render it as String does. The # flag draws it with ASCIIEdgeStyle.
+func (n *Node) Format(s fmt.State, verb rune) {
+	if n == nil {
+		io.WriteString(s, "<nil>")
+		return
+	}
+	var options []Option
+	switch verb {
+	case 'v':
+		if !s.Flag('+') {
+			options = append(options, WithMetaFunc(nil))
+		}
+	case 's':
+	default:
+		fmt.Fprintf(s, "%%!%c(*treeprint.Node)", verb)
+		return
+	}
+	if s.Flag('#') {
+		options = append(options, WithEdgeStyle(ASCIIEdgeStyle))
+	}
+	f := n.Options()
+	for _, opt := range options {
+		opt(&f)
+	}
+	n.PrintTo(s, f)
 }
 
 func (n *Node) SetValue(value Value) {
+	if n == nil {
+		return
+	}
+	old := n.Value
 	n.Value = value
+	n.MarkDirty()
+	n.emitSetValue(old)
 }
 
 func (n *Node) SetMetaValue(meta MetaValue) {
+	if n == nil {
+		return
+	}
+	old := n.Meta
 	n.Meta = meta
+	n.MarkDirty()
+	n.emitSetMeta(old)
 }
 
 func (n *Node) Prune(fn PruneFunc) {
-	temp := n.Nodes[:0]
-	for _, node := range n.Nodes {
+	if n == nil {
+		return
+	}
+	type frame struct {
+		node *Node
+		i    int
+		kept int
+	}
+	type removal struct {
+		parent, child *Node
+	}
+	var removed []removal
+	observed := len(n.observed()) > 0
+	n.children()
+	stack := []frame{{node: n}}
+	// a Node reached again from one of its own descendants is not descended into again
+	path := map[*Node]bool{n: true}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.node.Nodes) {
+			if top.kept < len(top.node.Nodes) {
+				top.node.Nodes = top.node.Nodes[:top.kept]
+				top.node.MarkDirty()
+			}
+			delete(path, top.node)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.node.Nodes[top.i]
+		top.i++
 		if fn(node) {
+			if observed {
+				removed = append(removed, removal{parent: top.node, child: node})
+			}
 			continue
 		}
-		temp = append(temp, node)
-		if len(node.Nodes) > 0 {
-			node.Prune(fn)
+		top.node.Nodes[top.kept] = node
+		node.index = top.kept
+		top.kept++
+		if !path[node] && len(node.children()) > 0 {
+			path[node] = true
+			stack = append(stack, frame{node: node})
 		}
 	}
-	n.Nodes = temp
+	for _, r := range removed {
+		r.parent.emitRemove(r.child)
+	}
 }
 
 func (n *Node) VisitAll(fn NodeVisitor) {
-	for _, node := range n.Nodes {
-		fn(node)
-
-		if len(node.Nodes) > 0 {
-			node.VisitAll(fn)
-			continue
-		}
-	}
+	n.VisitPreOrder(fn)
 }
 
 func (n *Node) ChildCount() int {
+	if n == nil {
+		return 0
+	}
 	return len(n.Nodes)
 }
 
+// printer writes the rendered output, keeping track of the number of bytes written.
+// After the first failed write all further writes are discarded.
 type printer struct {
-	io.Writer
-	pf PrinterOptions
+	w   io.Writer
+	pf  PrinterOptions
+	n   int64
+	err error
+
+	// link and blank are the prefix segments of a single level,
+	// they are computed once per render instead of once per line.
+	link  string
+	blank string
+	// mid and end are the edges of the nodes, see WithEdgeStyle.
+	mid EdgeType
+	end EdgeType
+	// ended tells for each level of the current path whether its last Node
+	// has been printed already, so there is nothing left to link to below.
+	ended []bool
+	// line and value are scratch buffers reused for every rendered line.
+	line  []byte
+	value bytes.Buffer
+
+	// windowed limits the output to the lines from up to but not including to,
+	// lineNo is the number of the line being written. See RenderLines.
+	windowed bool
+	from     int
+	to       int
+	lineNo   int
+	// stop is set once no more output is needed, either because of a write error
+	// or because the rest of the output is not wanted.
+	stop bool
+	// nodes is the number of nodes rendered so far, see WithMaxNodes.
+	nodes int
+	// unlimited is set while writing the output exempt from the byte limit,
+	// that is the truncation line and the fence.
+	unlimited bool
+	// markup is the number of bytes of the fence written so far.
+	markup int64
+	// escapeHTML is set while the output is written inside an HTML element, see WithHTMLPre.
+	escapeHTML bool
+	// path holds the nodes whose children are being rendered, reaching one of them again is a cycle.
+	path map[*Node]bool
+	// control holds the meta and value with their control characters escaped, see WithControlChars.
+	control []byte
+	// normalized holds the normalized meta and value, see WithNormalization.
+	normalized []byte
+	// truncated holds the meta and the truncated value, see WithTruncation.
+	truncated []byte
+	// ctx, when set, stops the rendering once done, see Render.
+	ctx context.Context
+	// hooked holds the output of the RenderHook.
+	hooked bytes.Buffer
+	// marked holds the meta and value with their matches highlighted, see HighlightMatches.
+	marked []byte
+	// expand tells whether the children of a branch are rendered, see WithCollapseUnmatched.
+	expand map[*Node]bool
+	// annotation holds the meta and value with their annotation applied, see WithAnnotations.
+	annotation []byte
+	// ruled holds the meta and value styled by the rules, see WithStyleRules.
+	ruled []byte
+	// onLine, when set, is called after writing the line of every Node
+	// with the offsets of the line in the output, see startLine.
+	onLine func(n *Node, depth int, start, end int64)
+	// pending is the line being written.
+	pending pendingLine
+	// levelOffset is added to the level of the rendered nodes to get their depth,
+	// for rendering lines relative to their level, see IncrementalPrinter.
+	levelOffset int
+	// eol and encoded hold the output with the line ending and the transformer applied,
+	// see WithLineEnding and WithTransformer.
+	eol     []byte
+	encoded []byte
+	// described holds the description of the Node being rendered, see SetDescription.
+	described []byte
 }
 
-func printNodes(p *printer, level int, levelsEnded []int, nodes []*Node) {
-	for i, node := range nodes {
-		edge := EdgeTypeMid
-		if i == len(nodes)-1 {
-			levelsEnded = append(levelsEnded, level)
-			edge = EdgeTypeEnd
-		}
-		printValues(p, level, levelsEnded, edge, node)
-		if len(node.Nodes) > 0 {
-			printNodes(p, level+1, levelsEnded, node.Nodes)
+type pendingLine struct {
+	node  *Node
+	depth int
+	start int64
+}
+
+// startLine records the Node whose line is about to be written, see onLine.
+func (p *printer) startLine(n *Node, depth int) {
+	if p.onLine != nil {
+		p.pending = pendingLine{node: n, depth: depth, start: p.n}
+	}
+}
+
+// endLine reports the line written since startLine, see onLine.
+// Nothing is reported for a line cut by the byte limit.
+func (p *printer) endLine() {
+	if p.onLine != nil && p.n > p.pending.start {
+		p.onLine(p.pending.node, p.pending.depth, p.pending.start, p.n)
+		p.pending = pendingLine{}
+	}
+}
+
+// newPrinter takes a printer from the pool, it should be released once the render is done.
+func newPrinter(w io.Writer, pf PrinterOptions) *printer {
+	p := printerPool.Get().(*printer)
+	p.w = w
+	p.pf = pf
+	style := pf.edgeStyle()
+	p.mid, p.end = style.Mid, style.End
+	p.link, p.blank = pf.segments()
+	if pf.levelMarkers {
+		// the depth is spelled out rather than drawn
+		p.link, p.blank = "", ""
+	}
+	return p
+}
+
+func (p *printer) Write(b []byte) (int, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+	if p.windowed {
+		p.writeWindow(b)
+		return len(b), p.err
+	}
+	return p.write(b)
+}
+
+func (p *printer) write(b []byte) (int, error) {
+	if p.escapeHTML {
+		b = []byte(html.EscapeString(string(b)))
+	}
+	if p.pf.lineEnding != "" || p.pf.transformer != nil {
+		var err error
+		if b, err = p.encode(b); err != nil {
+			p.err = err
+			p.stop = true
+			return 0, err
 		}
 	}
+	if p.pf.maxBytes > 0 && !p.unlimited && p.n-p.markup+int64(len(b)) > int64(p.pf.maxBytes) {
+		p.truncate(&LimitError{Limit: "bytes", Max: p.pf.maxBytes}, p.pf.message(BytesTruncated, p.pf.maxBytes)+"\n")
+		return 0, p.err
+	}
+	n, err := p.w.Write(b)
+	p.n += int64(n)
+	if err != nil {
+		p.err = err
+		p.stop = true
+	}
+	return n, err
 }
 
-func printValues(p *printer, level int, levelsEnded []int, edge EdgeType, node *Node) {
-	for i := 0; i < level; i++ {
-		if isEnded(levelsEnded, i) {
-			fmt.Fprint(p, strings.Repeat(" ", IndentSize+1))
-			continue
+// writeWindow writes only the parts of b that belong to the lines of the window.
+func (p *printer) writeWindow(b []byte) {
+	for len(b) > 0 && !p.stop {
+		i := bytes.IndexByte(b, '\n') + 1
+		if i == 0 {
+			i = len(b)
+		}
+		if p.lineNo >= p.from {
+			p.write(b[:i])
 		}
-		fmt.Fprintf(p, "%s%s", EdgeTypeLink, strings.Repeat(" ", IndentSize))
+		if b[i-1] == '\n' {
+			p.lineNo++
+			p.stop = p.lineNo >= p.to
+		}
+		b = b[i:]
 	}
+}
 
-	val := renderValue(p, level, node)
-	meta := node.Meta
+// edge returns the edge of a Node, depending on whether it's the last one of its siblings.
+func (p *printer) edge(last bool) EdgeType {
+	if last {
+		return p.end
+	}
+	return p.mid
+}
 
-	fmt.Fprintf(p, "%s ", edge)
-	if meta != nil {
-		p.pf.printMeta(meta, p)
+// setEnded records whether the last Node of the level has been printed.
+func (p *printer) setEnded(level int, ended bool) {
+	for len(p.ended) <= level {
+		p.ended = append(p.ended, false)
 	}
-	fmt.Fprintf(p, "%v\n", val)
+	p.ended[level] = ended
 }
 
-func isEnded(levelsEnded []int, level int) bool {
-	for _, l := range levelsEnded {
-		if l == level {
-			return true
+func printNodes(p *printer, level int, parent *Node, nodes []*Node) {
+	type frame struct {
+		parent *Node
+		nodes  []*Node
+		i      int
+		level  int
+	}
+	if p.path == nil {
+		p.path = make(map[*Node]bool)
+	}
+	// an explicit stack is used instead of recursion, so that very deep trees can be rendered
+	stack := []frame{{parent: parent, nodes: nodes, level: level}}
+	p.path[parent] = true
+	p.setEnded(level, false)
+	for len(stack) > 0 && !p.stop {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.nodes) {
+			delete(p.path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if p.pf.maxNodes > 0 && p.nodes >= p.pf.maxNodes {
+			var omitted int
+			for _, f := range stack {
+				omitted += countNodes(p, f.nodes[f.i:], f.level)
+			}
+			p.truncate(&LimitError{Limit: "nodes", Max: p.pf.maxNodes},
+				p.pf.message(NodesOmitted, omitted)+"\n")
+			break
 		}
+		if p.ctx != nil && p.nodes%contextCheckInterval == 0 {
+			if err := p.ctx.Err(); err != nil {
+				p.err, p.stop = err, true
+				break
+			}
+		}
+		p.nodes++
+		node := top.nodes[top.i]
+		top.i++
+		last := top.i == len(top.nodes)
+		if last {
+			p.ended[top.level] = true
+		}
+		if p.path[node] {
+			printCycle(p, top.level, last, node)
+			continue
+		}
+		printValues(p, top.level, last, node)
+		if !p.belowMaxDepth(node, top.level) || !p.expanded(node) {
+			continue
+		}
+		if nodes := p.pf.children(node); len(nodes) > 0 {
+			p.setEnded(top.level+1, false)
+			p.path[node] = true
+			stack = append(stack, frame{parent: node, nodes: nodes, level: top.level + 1})
+		}
+	}
+	for _, f := range stack {
+		delete(p.path, f.parent)
 	}
-	return false
 }
 
-func renderValue(p *printer, level int, node *Node) string {
-	buf := new(bytes.Buffer)
-	p.pf.printValue(node.Value, buf)
-	lines := strings.Split(buf.String(), "\n")
+// contextCheckInterval is the number of nodes rendered, or visited by WalkOrderContext,
+// between two checks of the context.
+const contextCheckInterval = 64
 
-	// If value does not contain multiple lines, return itself.
-	if len(lines) < 2 {
-		return buf.String()
+// countNodes counts the nodes that would be rendered for the given ones at the level,
+// without producing lazy children. The nodes rendered as cycles are counted once.
+func countNodes(p *printer, nodes []*Node, level int) int {
+	type frame struct {
+		parent *Node
+		nodes  []*Node
+		i      int
+		level  int
 	}
+	path := make(map[*Node]bool, len(p.path))
+	for node := range p.path {
+		path[node] = true
+	}
+	var count int
+	stack := []frame{{nodes: nodes, level: level}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.nodes) {
+			delete(path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.nodes[top.i]
+		top.i++
+		count++
+		if path[node] || p.pf.maxDepth > 0 && top.level+2 > p.pf.maxDepth {
+			continue
+		}
+		if len(node.Nodes) > 0 {
+			path[node] = true
+			stack = append(stack, frame{parent: node, nodes: node.Nodes, level: top.level + 1})
+		}
+	}
+	return count
+}
+
+// printValues renders a single Node line into the reusable line buffer and writes it out at once.
+func printValues(p *printer, level int, last bool, node *Node) {
+	p.startLine(node, level+1)
+	line := appendValues(p, p.line[:0], level, last, node)
+	p.line = append(line, '\n')
+	p.Write(p.line)
+	p.endLine()
+}
+
+// CycleMarker is appended to the line of a Node reached again from one of its own
+// descendants, its children are not rendered so that the output stays finite.
+const CycleMarker = " (cycle)"
 
-	// If value contains multiple lines,
-	// generate a padding and prefix each line with it.
-	pad := padding(level, node)
+// printCycle renders the line of a Node closing a cycle, see CycleMarker.
+func printCycle(p *printer, level int, last bool, node *Node) {
+	p.startLine(node, level+1)
+	line := appendValues(p, p.line[:0], level, last, node)
+	line = append(line, p.pf.message(Cycle, 0)...)
+	p.line = append(line, '\n')
+	p.Write(p.line)
+	p.endLine()
+}
 
-	for i := 1; i < len(lines); i++ {
-		lines[i] = fmt.Sprintf("%s%s", pad, lines[i])
+// appendValues appends the line of a single Node, without the line break.
+func appendValues(p *printer, line []byte, level int, last bool, node *Node) []byte {
+	depth := p.levelOffset + level + 1
+	if p.pf.levelMarkers {
+		line = append(line, p.pf.message(Level, depth)...)
+	} else {
+		line = appendPrefix(p, line, level)
+		line = append(line, p.edge(last)...)
+		line = append(line, ' ')
 	}
+	line = p.appendCheckbox(line, node)
 
-	return strings.Join(lines, "\n")
+	if b, ok := p.hook(node, depth, last); ok {
+		line = appendValue(p, line, level, b)
+		return p.appendDescription(line, level, node)
+	}
+	meta, value, multiline := p.format(node, depth)
+	meta, value = p.highlighted(node, meta, value)
+	meta, value = p.annotated(node, meta, value)
+	meta, value = p.styled(node, depth, meta, value)
+	line = append(line, meta...)
+	if multiline {
+		line = appendValue(p, line, level, value)
+	} else {
+		line = append(line, value...)
+	}
+	return p.appendDescription(line, level, node)
 }
 
-// padding returns a padding for the multiline values with correctly placed link edges.
-// It is generated by traversing the tree upwards (from leaf to the root of the tree)
-// and, on each level, checking if the Node the last one of its siblings.
-// If a Node is the last one, the padding on that level should be empty (there's nothing to link to below it).
-// If a Node is not the last one, the padding on that level should be the link edge so the sibling below is correctly connected.
-func padding(level int, node *Node) string {
-	links := make([]string, level+1)
+// appendPrefix appends the link edges of the levels above the given one.
+func appendPrefix(p *printer, line []byte, level int) []byte {
+	for i := 0; i < level; i++ {
+		if p.ended[i] {
+			line = append(line, p.blank...)
+			continue
+		}
+		line = append(line, p.link...)
+	}
+	return line
+}
 
-	for node.Root != nil {
-		if isLast(node) {
-			links[level] = strings.Repeat(" ", IndentSize+1)
-		} else {
-			links[level] = fmt.Sprintf("%s%s", EdgeTypeLink, strings.Repeat(" ", IndentSize))
+// appendValue appends the value, and if it contains multiple lines,
+// prefixes each but the first one with the padding.
+//
+// The padding has correctly placed link edges on each level up to and including the Node's own one,
+// depending on whether the Node or its ancestor on that level was the last one of its siblings.
+// If it was the last one, the padding on that level should be empty (there's nothing to link to below it).
+// If it was not the last one, the padding on that level should be the link edge so the sibling below is correctly connected.
+// The state is taken from the renderer rather than from Root pointers, so nodes shared
+// between trees (see Cow) are padded according to the tree being rendered.
+func appendValue(p *printer, line []byte, level int, value []byte) []byte {
+	for {
+		i := bytes.IndexByte(value, '\n')
+		if i < 0 {
+			return append(line, value...)
 		}
-		level--
-		node = node.Root
+		line = append(line, value[:i+1]...)
+		line = appendPrefix(p, line, level+1)
+		value = value[i+1:]
 	}
+}
 
-	return strings.Join(links, "")
+type EdgeType string
+
+// EdgeStyle is a set of edges to draw a tree with, see WithEdgeStyle.
+type EdgeStyle struct {
+	Link EdgeType
+	Mid  EdgeType
+	End  EdgeType
+}
+
+// ASCIIEdgeStyle draws the tree with ASCII characters only.
+var ASCIIEdgeStyle = EdgeStyle{Link: "|", Mid: "|--", End: "`--"}
+
+// edgeStyle returns the edges to draw with, the package ones unless a style is set.
+func (p PrinterOptions) edgeStyle() EdgeStyle {
+	if p.edges == (EdgeStyle{}) {
+		return EdgeStyle{Link: EdgeTypeLink, Mid: EdgeTypeMid, End: EdgeTypeEnd}
+	}
+	return p.edges
 }
 
-// isLast checks if the Node is the last one in the slice of its parent children
-func isLast(n *Node) bool {
-	return n == n.Root.FindLastNode()
+// Edges returns the edges the tree is drawn with, see WithEdgeStyle.
+func (p PrinterOptions) Edges() EdgeStyle {
+	return p.edgeStyle()
 }
 
-type EdgeType string
+// segments returns the prefix segments of a level, below a Node with siblings left and without.
+func (p PrinterOptions) segments() (link, blank string) {
+	return string(p.edgeStyle().Link) + strings.Repeat(" ", p.indentSize()), strings.Repeat(" ", p.indentSize()+1)
+}
 
+// The edges drawn by default.
+//
+// Deprecated: changing them affects every tree of the program and races with
+// concurrent renders, use WithEdgeStyle instead.
 var (
 	EdgeTypeLink EdgeType = "│"
 
@@ -379,14 +1357,60 @@ var (
 )
 
 // IndentSize is the number of spaces per tree level.
+//
+// Deprecated: changing it affects every tree of the program and races with
+// concurrent renders, use WithIndentSize instead.
 var IndentSize = 3
 
-// New Generates new tree
-func New() Tree {
-	return &Node{Value: "."}
+// WithIndentSize sets the number of spaces per tree level, instead of IndentSize.
+func WithIndentSize(n int) Option {
+	return func(p *PrinterOptions) {
+		if n < 0 {
+			n = 0
+		}
+		p.indent, p.indentSet = n, true
+	}
+}
+
+// indentSize returns the number of spaces per tree level.
+func (p PrinterOptions) indentSize() int {
+	if p.indentSet {
+		return p.indent
+	}
+	return IndentSize
+}
+
+// Indent returns the number of spaces per tree level, see WithIndentSize.
+func (p PrinterOptions) Indent() int {
+	return p.indentSize()
 }
 
-// NewWithRoot Generates new tree with the given root value
-func NewWithRoot(root Value) Tree {
-	return &Node{Value: root}
+// New Generates new tree, the options are used by the methods rendering
+// without explicit printer options, such as String, see Options.
+func New(options ...Option) Tree {
+	return NewWithRoot(".", options...)
+}
+
+// NewWithRoot Generates new tree with the given root value,
+// the options are used by the methods rendering without explicit printer options.
+func NewWithRoot(root Value, options ...Option) Tree {
+	n := &Node{Value: root}
+	if len(options) > 0 {
+		pf := NewPrinter(options...)
+		n.options = &pf
+	}
+	return n
+}
+
+// Options returns the printer options given to New or NewWithRoot for the tree the Node belongs to,
+// or the default ones. They are used by String, WriteTo, Format and MarshalText.
+func (n *Node) Options() PrinterOptions {
+	root := n
+	for root != nil && root.Root != nil {
+		root = root.Root
+	}
+	if root == nil || root.options == nil {
+		return NewPrinter()
+	}
+	return *root.options
 }
@@ -1,6 +1,12 @@
 package treeprint
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -315,3 +321,281 @@ func TestVisitAll(t *testing.T) {
 	assert.Equal(expectedNodeValues, visitedNodeValues)
 
 }
+
+type limitWriter struct {
+	limit int
+}
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		n := w.limit
+		w.limit = 0
+		return n, errors.New("limit reached")
+	}
+	w.limit -= len(p)
+	return len(p), nil
+}
+
+func TestWriteTo(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("one").AddMetaNode("meta", "two")
+	tree.AddNode("three")
+
+	buf := new(bytes.Buffer)
+	n, err := tree.WriteTo(buf)
+	assert.NoError(err)
+	assert.Equal(tree.String(), buf.String())
+	assert.Equal(int64(buf.Len()), n)
+
+	n, err = tree.PrintTo(&limitWriter{limit: 10}, NewPrinter())
+	assert.EqualError(err, "limit reached")
+	assert.Equal(int64(10), n)
+}
+
+func TestString(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddMetaNode("meta", "one")
+	assert.Equal(".\n└── [meta]  one\n", tree.String())
+	assert.Equal(string(tree.Bytes(NewPrinter())), tree.String())
+	// the zero options have no meta and value printers
+	assert.Equal("\n└── \n", string(tree.Bytes(PrinterOptions{})))
+
+	tree = New(WithEdgeStyle(ASCIIEdgeStyle))
+	tree.AddBranch("one").AddNode("two")
+	assert.Equal(".\n`-- one\n    `-- two\n", tree.String())
+}
+
+func benchmarkTree(width, depth int) Tree {
+	tree := New()
+	var fill func(branch Tree, depth int)
+	fill = func(branch Tree, depth int) {
+		for i := 0; i < width; i++ {
+			if depth == 0 {
+				branch.AddMetaNode(i, "leaf")
+				continue
+			}
+			fill(branch.AddBranch("branch"), depth-1)
+		}
+	}
+	fill(tree, depth)
+	return tree
+}
+
+func BenchmarkRenderWide(b *testing.B) {
+	tree := benchmarkTree(1000, 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = tree.WriteTo(io.Discard)
+	}
+}
+
+func BenchmarkRenderDeep(b *testing.B) {
+	tree := benchmarkTree(3, 8)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = tree.WriteTo(io.Discard)
+	}
+}
+
+func TestAppendBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("one").AddNode("two")
+
+	buf := tree.AppendBytes([]byte("tree:\n"), NewPrinter())
+	assert.Equal("tree:\n"+tree.String(), string(buf))
+
+	reused := tree.AppendBytes(buf[:0], NewPrinter())
+	assert.Equal(tree.String(), string(reused))
+	assert.Equal(&buf[0], &reused[0])
+
+	// the returned slice is not shared with the pooled buffers
+	b := tree.Bytes(NewPrinter())
+	New().Bytes(NewPrinter())
+	assert.Equal(tree.String(), string(b))
+}
+
+func BenchmarkBytes(b *testing.B) {
+	tree := benchmarkTree(10, 2)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Bytes(NewPrinter())
+	}
+}
+
+func TestRenderLines(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("one").AddNode("a\nb").AddNode("c")
+	tree.AddNode("two")
+	lines := strings.Split(strings.TrimSuffix(tree.String(), "\n"), "\n")
+	assert.Len(lines, 6)
+
+	f := NewPrinter()
+	assert.Equal(lines, tree.RenderLines(f, 0, 100))
+	assert.Equal(lines[2:4], tree.RenderLines(f, 2, 4))
+	assert.Equal(lines[5:], tree.RenderLines(f, 5, 6))
+	assert.Equal(lines[:1], tree.RenderLines(f, -1, 1))
+	assert.Nil(tree.RenderLines(f, 6, 10))
+	assert.Nil(tree.RenderLines(f, 3, 3))
+
+	// rendering stops after the window
+	var printed int
+	counting := NewPrinter(WithValuePrint(func(v Value, w io.Writer) {
+		printed++
+		io.WriteString(w, v.(string))
+	}))
+	assert.Equal(lines[:2], tree.RenderLines(counting, 0, 2))
+	assert.Equal(2, printed)
+}
+
+func TestIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddNode("a").AddNode("b").AddNode("c")
+	root := tree.(*Node)
+	a, b, c := root.Nodes[0], root.Nodes[1], root.Nodes[2]
+
+	assert.Equal(-1, tree.Index())
+	assert.True(tree.IsLast())
+	assert.Equal([]int{0, 1, 2}, []int{a.Index(), b.Index(), c.Index()})
+	assert.False(b.IsLast())
+	assert.True(c.IsLast())
+
+	tree.Prune(func(item *Node) bool { return item == a })
+	assert.Equal([]int{0, 1}, []int{b.Index(), c.Index()})
+	assert.Equal(-1, a.Index())
+
+	// rearranged directly, the cached index is fixed on the next call
+	root.Nodes[0], root.Nodes[1] = c, b
+	assert.Equal(0, c.Index())
+	assert.Equal(0, c.index)
+	assert.True(b.IsLast())
+}
+
+func TestMaxNodes(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	one := tree.AddBranch("one")
+	one.AddNode("a")
+	one.AddBranch("b").AddNode("c")
+	tree.AddNode("two")
+
+	assert.Equal(`.
+├── one
+│   ├── a
+│   └── b
+… output truncated (2 nodes omitted)`, tree.Print(NewPrinter(WithMaxNodes(3))))
+	assert.Equal(`.
+├── one
+… output truncated (1 nodes omitted)`, tree.Print(NewPrinter(WithMaxNodes(1), WithMaxDepth(1))))
+	assert.Equal(tree.String(), string(tree.Bytes(NewPrinter(WithMaxNodes(5)))))
+
+	buf := new(bytes.Buffer)
+	_, err := tree.PrintToParallel(buf, NewPrinter(WithMaxNodes(3)), 2)
+	assert.NoError(err)
+	assert.Equal(string(tree.Bytes(NewPrinter(WithMaxNodes(3)))), buf.String())
+}
+
+func TestFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddMetaBranch("m", "one").AddNode("a")
+	tree.AddNode("two")
+
+	assert.Equal(`.
+├── one
+│   └── a
+└── two
+`, fmt.Sprintf("%v", tree))
+	assert.Equal(tree.String(), fmt.Sprintf("%+v", tree))
+	assert.Equal(tree.String(), fmt.Sprintf("%s", tree))
+	assert.Equal(`.
+|-- [m]  one
+|   `+"`"+`-- a
+`+"`"+`-- two
+`, fmt.Sprintf("%#+v", tree))
+	assert.Equal("%!d(*treeprint.Node)", fmt.Sprintf("%d", tree))
+}
+
+func TestEdgeStyle(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("one").AddNode("a\nb")
+	tree.AddNode("two")
+
+	pf := NewPrinter(WithEdgeStyle(ASCIIEdgeStyle))
+	expected := `.
+|-- one
+|   ` + "`" + `-- a
+|       b
+` + "`" + `-- two
+`
+	assert.Equal(expected, string(tree.Bytes(pf)))
+	assert.Equal(int64(len(expected)), tree.EstimateSize(pf))
+}
+
+func TestNewOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New(WithEdgeStyle(ASCIIEdgeStyle), WithIndentSize(1))
+	one := tree.AddBranch("one")
+	one.AddMetaNode("m", "two")
+	tree.AddNode("three")
+
+	expected := ".\n|-- one\n| `-- [m]  two\n`-- three\n"
+	assert.Equal(expected, tree.String())
+	assert.Equal(expected, fmt.Sprintf("%+v", tree))
+	assert.Equal("|-- one\n`-- [m]  two\n", one.String())
+	b, err := tree.MarshalText()
+	assert.NoError(err)
+	assert.Equal(expected, string(b))
+	assert.Equal(".\n├── one\n│ └── [m]  two\n└── three", tree.Print(NewPrinter(WithIndentSize(1))))
+	assert.Equal(int64(len(expected)), tree.EstimateSize(tree.Options()))
+
+	root := NewWithRoot("root", WithMetaFunc(nil))
+	root.AddMetaNode("m", "leaf")
+	assert.Equal("root\n└── leaf\n", root.String())
+	assert.Equal("root\n└── [m]  leaf\n", NewWithRoot("root").AddMetaNode("m", "leaf").String())
+}
+
+func TestRender(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New(WithEdgeStyle(ASCIIEdgeStyle))
+	for i := 0; i < 1000; i++ {
+		tree.AddNode(i)
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(tree.Render(context.Background(), &buf, WithMaxDepth(1)))
+	assert.Equal(tree.String(), buf.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var printed int
+	buf.Reset()
+	err := tree.Render(ctx, &buf, WithValuePrint(func(v Value, w io.Writer) {
+		if printed++; printed == 100 {
+			cancel()
+		}
+		fmt.Fprint(w, v)
+	}))
+	assert.ErrorIs(err, context.Canceled)
+	assert.Less(printed, 100+contextCheckInterval+1)
+	assert.True(strings.HasPrefix(tree.String(), buf.String()))
+
+	assert.ErrorIs(tree.Render(ctx, &buf), context.Canceled)
+}
@@ -0,0 +1,106 @@
+// Package treetest checks rendered trees against golden files in tests.
+//
+// The golden files are rewritten with the rendered trees instead of being compared
+// when the tests are run with the -update flag, or with TREETEST_UPDATE=1 when
+// the test binary defines its own -update flag after importing this package:
+//
+//	go test ./... -update
+package treetest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ououmania/treeprint"
+	"github.com/ououmania/treeprint/internal/lcs"
+)
+
+// UpdateEnv is the environment variable enabling the update of the golden files.
+const UpdateEnv = "TREETEST_UPDATE"
+
+func init() {
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "update the golden files of treetest")
+	}
+}
+
+// Updating reports whether the golden files are being updated.
+func Updating() bool {
+	if v := os.Getenv(UpdateEnv); v != "" && v != "0" {
+		return true
+	}
+	f := flag.Lookup("update")
+	return f != nil && f.Value.String() == "true"
+}
+
+// AssertRender renders the tree with its printer options changed by the given ones,
+// see treeprint.Node.Options, and compares the output with the golden file,
+// reporting the differences line by line on mismatch. When updating, the golden file
+// is written instead, along with its missing directories. It reports whether the output matched.
+func AssertRender(t testing.TB, tree treeprint.Tree, goldenPath string, options ...treeprint.Option) bool {
+	t.Helper()
+	f := tree.Options()
+	for _, opt := range options {
+		opt(&f)
+	}
+	got := tree.Bytes(f)
+	if Updating() {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+			t.Fatalf("treetest: %v", err)
+		}
+		if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+			t.Fatalf("treetest: %v", err)
+		}
+		t.Logf("treetest: updated %s", goldenPath)
+		return true
+	}
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Errorf("treetest: %v (run the tests with -update to create it)", err)
+		return false
+	}
+	if bytes.Equal(want, got) {
+		return true
+	}
+	t.Errorf("treetest: output differs from %s (-want +got):\n%s", goldenPath,
+		Diff(string(want), string(got), os.Getenv("NO_COLOR") == ""))
+	return false
+}
+
+const (
+	colorRemoved = "\x1b[31m"
+	colorAdded   = "\x1b[32m"
+	colorReset   = "\x1b[0m"
+)
+
+// Diff returns the line diff of want and got, the lines only in want prefixed with "-",
+// the ones only in got with "+" and the common ones with a space.
+// With color the removed lines are red and the added ones green.
+func Diff(want, got string, color bool) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	var out strings.Builder
+	line := func(marker byte, s, c string) {
+		if color && c != "" {
+			fmt.Fprintf(&out, "%s%c %s%s\n", c, marker, s, colorReset)
+			return
+		}
+		fmt.Fprintf(&out, "%c %s\n", marker, s)
+	}
+	for _, op := range lcs.Diff(len(a), len(b), func(i, j int) bool { return a[i] == b[j] }) {
+		switch op.Kind {
+		case ' ':
+			line(' ', a[op.A], "")
+		case '-':
+			line('-', a[op.A], colorRemoved)
+		default:
+			line('+', b[op.B], colorAdded)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,64 @@
+package treetest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ououmania/treeprint"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder records the failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertRender(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := treeprint.New()
+	tree.AddBranch("one").AddNode("two")
+	tree.AddNode("three")
+	assert.True(AssertRender(t, tree, "testdata/simple.golden"))
+	assert.True(AssertRender(t, tree, "testdata/simple_ascii.golden",
+		treeprint.WithEdgeStyle(treeprint.ASCIIEdgeStyle)))
+
+	t.Setenv("NO_COLOR", "1")
+	r := &recorder{TB: t}
+	tree.AddNode("four")
+	assert.False(AssertRender(r, tree, "testdata/simple.golden"))
+	if assert.Len(r.errors, 1) {
+		assert.Equal("treetest: output differs from testdata/simple.golden (-want +got):\n"+
+			"  .\n"+
+			"  ├── one\n"+
+			"  │   └── two\n"+
+			"- └── three\n"+
+			"+ ├── three\n"+
+			"+ └── four\n", r.errors[0])
+	}
+
+	golden := filepath.Join(t.TempDir(), "new", "tree.golden")
+	r = &recorder{TB: t}
+	assert.False(AssertRender(r, tree, golden))
+	assert.Len(r.errors, 1)
+
+	t.Setenv(UpdateEnv, "1")
+	assert.True(AssertRender(t, tree, golden))
+	b, err := os.ReadFile(golden)
+	assert.NoError(err)
+	assert.Equal(tree.String(), string(b))
+}
+
+func TestDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("  a\n\x1b[31m- b\x1b[0m\n\x1b[32m+ c\x1b[0m\n", Diff("a\nb\n", "a\nc\n", true))
+	assert.Equal("  a\n", Diff("a\n", "a\n", false))
+}
@@ -0,0 +1,403 @@
+// Package treeview is a minimal interactive terminal viewer for treeprint trees,
+// with arrow-key navigation, expanding and collapsing of branches, and search.
+//
+// A Viewer holds the state and renders it, keys are fed to it by Press, so that it can
+// be driven by any terminal library, e.g. from a bubbletea model with Press(msg.String()).
+// Run drives it on its own from a terminal in raw mode:
+//
+//	state, _ := term.MakeRaw(int(os.Stdin.Fd()))
+//	defer term.Restore(int(os.Stdin.Fd()), state)
+//	treeview.New(tree).Run(os.Stdin, os.Stdout)
+package treeview
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ououmania/treeprint"
+)
+
+// Viewer is the state of an interactive tree view. Only the root is expanded at first.
+type Viewer struct {
+	// Height is the number of rows shown by View besides the status line,
+	// the rows are scrolled to keep the cursor visible. Zero shows all of them.
+	Height int
+
+	root     *treeprint.Node
+	pf       treeprint.PrinterOptions
+	expanded map[*treeprint.Node]bool
+	rows     []row
+	cursor   int
+	offset   int
+
+	searching bool
+	query     string
+	status    string
+	quit      bool
+}
+
+// row is a visible Node along with the prefix of its line and the one
+// of the extra lines of a multiline value.
+type row struct {
+	node   *treeprint.Node
+	prefix string
+	next   string
+}
+
+// New creates a Viewer of the tree, drawn with the printer options of the tree.
+func New(t treeprint.Tree) *Viewer {
+	root := t.(*treeprint.Node)
+	v := &Viewer{
+		root:     root,
+		pf:       root.Options(),
+		expanded: map[*treeprint.Node]bool{root: true},
+	}
+	v.layout()
+	return v
+}
+
+// Selected returns the Node under the cursor.
+func (v *Viewer) Selected() *treeprint.Node {
+	return v.rows[v.cursor].node
+}
+
+// Quit reports whether the viewer was asked to quit.
+func (v *Viewer) Quit() bool {
+	return v.quit
+}
+
+// Press handles a key, either a single character or one of "up", "down", "left",
+// "right", "enter", "backspace", "esc" and "ctrl+c". The keys are:
+//
+//	up, k         move up
+//	down, j       move down
+//	right, l      expand the branch, or move to its first child
+//	left, h       collapse the branch, or move to its parent
+//	enter, space  toggle the branch
+//	/             search, typing the query and confirming with enter
+//	n             move to the next match of the search
+//	q, ctrl+c     quit
+//
+// The search only goes through the nodes already loaded, it produces no lazy children.
+func (v *Viewer) Press(key string) {
+	if v.searching {
+		v.pressSearch(key)
+		return
+	}
+	v.status = ""
+	node := v.Selected()
+	switch key {
+	case "up", "k":
+		v.move(v.cursor - 1)
+	case "down", "j":
+		v.move(v.cursor + 1)
+	case "right", "l":
+		if !v.expanded[node] && len(children(node)) > 0 {
+			v.expand(node)
+		} else if v.expanded[node] {
+			v.move(v.cursor + 1)
+		}
+	case "left", "h":
+		if v.expanded[node] && node != v.root {
+			delete(v.expanded, node)
+			v.layout()
+		} else if node.Root != nil {
+			v.moveTo(node.Root)
+		}
+	case "enter", " ":
+		if v.expanded[node] && node != v.root {
+			delete(v.expanded, node)
+			v.layout()
+		} else if len(children(node)) > 0 {
+			v.expand(node)
+		}
+	case "/":
+		v.searching, v.query = true, ""
+	case "n":
+		v.search()
+	case "q", "ctrl+c":
+		v.quit = true
+	}
+}
+
+func (v *Viewer) pressSearch(key string) {
+	switch key {
+	case "enter":
+		v.searching = false
+		v.search()
+	case "esc":
+		v.searching = false
+	case "ctrl+c":
+		v.quit = true
+	case "backspace":
+		if r := []rune(v.query); len(r) > 0 {
+			v.query = string(r[:len(r)-1])
+		}
+	default:
+		if len([]rune(key)) == 1 {
+			v.query += key
+		}
+	}
+}
+
+// search moves to the first loaded Node after the cursor whose value contains the query,
+// ignoring case and wrapping around, and expands its ancestors.
+func (v *Viewer) search() {
+	if v.query == "" {
+		return
+	}
+	query := strings.ToLower(v.query)
+	nodes := loaded(v.root)
+	start := 0
+	for i, node := range nodes {
+		if node == v.Selected() {
+			start = i + 1
+		}
+	}
+	for i := range nodes {
+		node := nodes[(start+i)%len(nodes)]
+		if strings.Contains(strings.ToLower(fmt.Sprint(node.Value)), query) {
+			for _, ancestor := range node.Ancestors() {
+				v.expanded[ancestor] = true
+			}
+			v.layout()
+			v.moveTo(node)
+			return
+		}
+	}
+	v.status = fmt.Sprintf("no match for %q", v.query)
+}
+
+func (v *Viewer) expand(node *treeprint.Node) {
+	v.expanded[node] = true
+	v.layout()
+}
+
+func (v *Viewer) move(i int) {
+	if i < 0 || i >= len(v.rows) {
+		return
+	}
+	v.cursor = i
+	if v.Height > 0 {
+		if v.cursor < v.offset {
+			v.offset = v.cursor
+		} else if v.cursor >= v.offset+v.Height {
+			v.offset = v.cursor - v.Height + 1
+		}
+	}
+}
+
+func (v *Viewer) moveTo(node *treeprint.Node) {
+	for i, r := range v.rows {
+		if r.node == node {
+			v.move(i)
+			return
+		}
+	}
+}
+
+// layout computes the visible rows, keeping the cursor on the same Node if still visible.
+func (v *Viewer) layout() {
+	var selected *treeprint.Node
+	if len(v.rows) > 0 {
+		selected = v.Selected()
+	}
+	v.rows = v.rows[:0]
+	v.rows = append(v.rows, row{node: v.root})
+	type frame struct {
+		parent *treeprint.Node
+		nodes  []*treeprint.Node
+		i      int
+		prefix string
+	}
+	edges := v.pf.Edges()
+	link := string(edges.Link) + strings.Repeat(" ", v.pf.Indent())
+	blank := strings.Repeat(" ", v.pf.Indent()+1)
+	stack := []frame{{parent: v.root, nodes: children(v.root)}}
+	// a Node reached again from one of its own descendants is not expanded again
+	path := map[*treeprint.Node]bool{v.root: true}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.nodes) {
+			delete(path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.nodes[top.i]
+		top.i++
+		last := top.i == len(top.nodes)
+		edge, next := edges.Mid, link
+		if last {
+			edge, next = edges.End, blank
+		}
+		v.rows = append(v.rows, row{node: node, prefix: top.prefix + string(edge) + " ", next: top.prefix + next})
+		if v.expanded[node] && !path[node] {
+			path[node] = true
+			stack = append(stack, frame{parent: node, nodes: children(node), prefix: top.prefix + next})
+		}
+	}
+	v.cursor = 0
+	// the tree may have shrunk below the scrolled rows
+	v.offset = v.clampOffset()
+	for selected != nil {
+		for i, r := range v.rows {
+			if r.node == selected {
+				v.move(i)
+				return
+			}
+		}
+		// the selected Node got hidden, its closest visible ancestor is selected instead
+		selected = selected.Root
+	}
+}
+
+// clampOffset returns the scroll offset kept within the rows, so that the last
+// page is full when the rows shrink or Height grows.
+func (v *Viewer) clampOffset() int {
+	offset := v.offset
+	if last := len(v.rows) - v.Height; offset > last {
+		offset = last
+	}
+	if offset < 0 || v.Height <= 0 {
+		offset = 0
+	}
+	return offset
+}
+
+// View renders the visible rows, the one under the cursor marked with "> ",
+// followed by a status line.
+func (v *Viewer) View() string {
+	var b strings.Builder
+	offset := v.clampOffset()
+	rows := v.rows[offset:]
+	if v.Height > 0 && len(rows) > v.Height {
+		rows = rows[:v.Height]
+	}
+	for i, r := range rows {
+		if offset+i == v.cursor {
+			b.WriteString("> ")
+		} else {
+			b.WriteString("  ")
+		}
+		b.WriteString(r.prefix)
+		// the extra lines of a multiline value are aligned with the first one
+		next := "\n  " + r.next
+		switch {
+		case r.node == v.root:
+		case v.expanded[r.node]:
+			b.WriteString("▾ ")
+			next += "  "
+		case len(children(r.node)) > 0:
+			b.WriteString("▸ ")
+			next += "  "
+		}
+		b.WriteString(strings.ReplaceAll(v.pf.FormatNode(r.node), "\n", next))
+		b.WriteString("\n")
+	}
+	switch {
+	case v.searching:
+		b.WriteString("/" + v.query)
+	case v.status != "":
+		b.WriteString(v.status)
+	default:
+		fmt.Fprintf(&b, "%d/%d", v.cursor+1, len(v.rows))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// Run shows the viewer on out and handles the keys read from in until quit.
+// The terminal is expected to be in raw mode.
+func (v *Viewer) Run(in io.Reader, out io.Writer) error {
+	buf := make([]byte, 64)
+	for !v.quit {
+		// lines end with CRLF in raw mode
+		screen := "\x1b[H\x1b[2J" + strings.ReplaceAll(v.View(), "\n", "\r\n")
+		if _, err := io.WriteString(out, screen); err != nil {
+			return err
+		}
+		n, err := in.Read(buf)
+		for _, key := range decodeKeys(buf[:n]) {
+			v.Press(key)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeKeys decodes the keys read from a terminal in raw mode.
+func decodeKeys(b []byte) []string {
+	var keys []string
+	for len(b) > 0 {
+		switch {
+		case len(b) >= 3 && b[0] == 0x1b && b[1] == '[':
+			switch b[2] {
+			case 'A':
+				keys = append(keys, "up")
+			case 'B':
+				keys = append(keys, "down")
+			case 'C':
+				keys = append(keys, "right")
+			case 'D':
+				keys = append(keys, "left")
+			}
+			b = b[3:]
+			continue
+		case b[0] == 0x1b:
+			keys = append(keys, "esc")
+		case b[0] == '\r' || b[0] == '\n':
+			keys = append(keys, "enter")
+		case b[0] == 0x7f || b[0] == 0x08:
+			keys = append(keys, "backspace")
+		case b[0] == 0x03:
+			keys = append(keys, "ctrl+c")
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, string(r))
+			b = b[size:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// loaded returns the loaded descendants of the Node in pre-order, without producing
+// the lazy children. A Node reached again from one of its own descendants is not walked again.
+func loaded(n *treeprint.Node) []*treeprint.Node {
+	type frame struct {
+		parent *treeprint.Node
+		i      int
+	}
+	var nodes []*treeprint.Node
+	stack := []frame{{parent: n}}
+	path := map[*treeprint.Node]bool{n: true}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.parent.Nodes) {
+			delete(path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.parent.Nodes[top.i]
+		top.i++
+		nodes = append(nodes, node)
+		if !path[node] {
+			path[node] = true
+			stack = append(stack, frame{parent: node})
+		}
+	}
+	return nodes
+}
+
+// children returns the children of the Node, producing the lazy ones.
+func children(n *treeprint.Node) []*treeprint.Node {
+	return n.NodesAtDepth(1)
+}
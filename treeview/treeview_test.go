@@ -0,0 +1,235 @@
+package treeview
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ououmania/treeprint"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTree() treeprint.Tree {
+	tree := treeprint.New()
+	one := tree.AddMetaBranch("m", "one")
+	one.AddNode("a")
+	one.AddBranch("b").AddNode("needle")
+	tree.AddNode("two")
+	return tree
+}
+
+func TestViewer(t *testing.T) {
+	assert := assert.New(t)
+
+	v := New(testTree())
+	assert.Equal(`> .
+  ├── ▸ [m]  one
+  └── two
+1/3
+`, v.View())
+
+	v.Press("down")
+	v.Press("right")
+	assert.Equal(`  .
+> ├── ▾ [m]  one
+  │   ├── a
+  │   └── ▸ b
+  └── two
+2/5
+`, v.View())
+
+	v.Press("right")
+	v.Press("j")
+	v.Press("j")
+	assert.Equal("two", v.Selected().Value)
+	v.Press("up")
+	v.Press("left")
+	assert.Equal("one", v.Selected().Value)
+	v.Press("enter")
+	assert.Equal(`  .
+> ├── ▸ [m]  one
+  └── two
+2/3
+`, v.View())
+
+	for _, key := range []string{"/", "N", "e", "x", "backspace"} {
+		v.Press(key)
+	}
+	assert.True(strings.HasSuffix(v.View(), "\n/Ne\n"))
+	v.Press("enter")
+	assert.Equal("needle", v.Selected().Value)
+	assert.Equal(`  .
+  ├── ▾ [m]  one
+  │   ├── a
+  │   └── ▾ b
+> │       └── needle
+  └── two
+5/6
+`, v.View())
+
+	v.Press("/")
+	v.Press("z")
+	v.Press("enter")
+	assert.True(strings.HasSuffix(v.View(), "\nno match for \"z\"\n"))
+
+	v.Height = 2
+	v.Press("k")
+	assert.Equal(`  │   ├── a
+> │   └── ▾ b
+4/6
+`, v.View())
+
+	v.Press("q")
+	assert.True(v.Quit())
+}
+
+func TestViewerEdges(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := treeprint.New(treeprint.WithEdgeStyle(treeprint.ASCIIEdgeStyle), treeprint.WithIndentSize(1))
+	tree.AddBranch("one").AddNode("a")
+	tree.AddNode("two")
+	v := New(tree)
+	v.Press("down")
+	v.Press("right")
+	assert.Equal(`  .
+> |-- ▾ one
+  | `+"`"+`-- a
+  `+"`"+`-- two
+2/4
+`, v.View())
+}
+
+func TestViewerMeta(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := treeprint.New(treeprint.WithMetaFunc(func(m treeprint.MetaValue, w io.Writer) {
+		fmt.Fprintf(w, "<%v>", m)
+	}))
+	tree.AddMetaNode("m", "one")
+	assert.Equal(`> .
+  └── <m>  one
+1/2
+`, New(tree).View())
+}
+
+func TestViewerScrollShrink(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := treeprint.New()
+	one := tree.AddBranch("one")
+	for _, value := range []string{"a", "b", "c", "d"} {
+		one.AddNode(value)
+	}
+	tree.AddNode("two")
+	v := New(tree)
+	v.Height = 3
+	v.Press("down")
+	v.Press("right")
+	for i := 0; i < 5; i++ {
+		v.Press("down")
+	}
+	assert.Equal("two", v.Selected().Value)
+	v.Press("up")
+	v.Press("left")
+	v.Press("left")
+	assert.Equal("one", v.Selected().Value)
+	// the rows shrank from 7 to 3, they are all shown again
+	assert.Equal(`  .
+> ├── ▸ one
+  └── two
+2/3
+`, v.View())
+
+	v.Height = 10
+	assert.Equal(`  .
+> ├── ▸ one
+  └── two
+2/3
+`, v.View())
+}
+
+func TestViewerCycle(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := treeprint.New().(*treeprint.Node)
+	one := tree.AddBranch("one").(*treeprint.Node)
+	one.AddNode("a")
+	// bypass AddTree, which refuses to create cycles
+	one.Nodes = append(one.Nodes, tree)
+	v := New(tree)
+	v.Press("down")
+	v.Press("right")
+	v.Press("down")
+	v.Press("down")
+	v.Press("right")
+	assert.Equal(`  .
+  └── ▾ one
+      ├── a
+>     └── .
+4/4
+`, v.View())
+}
+
+func TestViewerMultiline(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := treeprint.NewWithRoot("r\ns")
+	tree.AddBranch("one\ntwo").AddNode("a\nb")
+	tree.AddNode("c\nd")
+	v := New(tree)
+	v.Press("down")
+	v.Press("right")
+	assert.Equal(`  r
+  s
+> ├── ▾ one
+  │     two
+  │   └── a
+  │       b
+  └── c
+      d
+2/4
+`, v.View())
+}
+
+func TestViewerSearchLazy(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls int
+	tree := treeprint.New()
+	lazy := tree.AddBranch("lazy").(*treeprint.Node)
+	lazy.SetChildrenFunc(func() []*treeprint.Node {
+		calls++
+		return []*treeprint.Node{{Value: "deep"}}
+	})
+	tree.AddNode("two")
+	v := New(tree)
+	calls = 0
+	for _, key := range []string{"/", "d", "e", "e", "p", "enter", "n", "n"} {
+		v.Press(key)
+	}
+	assert.Zero(calls, "the search produces no lazy children")
+	assert.True(strings.HasSuffix(v.View(), "\nno match for \"deep\"\n"))
+
+	v.Press("down")
+	v.Press("right")
+	v.Press("/")
+	v.Press("p")
+	v.Press("enter")
+	assert.Equal("deep", v.Selected().Value)
+}
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+
+	v := New(testTree())
+	out := new(bytes.Buffer)
+	assert.NoError(v.Run(strings.NewReader("\x1b[B\x1b[C\x1b[Bq"), out))
+	assert.True(v.Quit())
+	assert.Equal("a", v.Selected().Value)
+	assert.Contains(out.String(), "\x1b[H\x1b[2J> .\r\n")
+
+	assert.Equal([]string{"up", "esc", "enter", "backspace", "ctrl+c", "é", "�"}, decodeKeys([]byte("\x1b[A\x1b\r\x7f\x03é\xff")))
+}
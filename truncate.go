@@ -0,0 +1,72 @@
+package treeprint
+
+import "bytes"
+
+// TruncationHook function type for receiving the full value of a Node whose value
+// was truncated, see WithTruncation. The full value is formatted as rendered, but not cut.
+type TruncationHook func(n *Node, full string)
+
+// WithTruncation cuts every line of the values wider than width columns, as measured
+// by PrinterOptions.Measure, ending them with the ellipsis so that they fit, for dense
+// single-screen summaries. The meta values are left untouched. Zero means no limit.
+func WithTruncation(width int, ellipsis string) Option {
+	return func(p *PrinterOptions) {
+		p.truncateWidth = width
+		p.ellipsis = ellipsis
+	}
+}
+
+// WithTruncationHook has the full values cut by WithTruncation passed to fn, so that
+// exporters can reveal them, e.g. as tooltips or footnotes. With a ValueCache,
+// fn is only called when the value is formatted anew.
+func WithTruncationHook(fn TruncationHook) Option {
+	return func(p *PrinterOptions) {
+		p.truncationHook = fn
+	}
+}
+
+// appendTruncated appends the value with its lines cut to the truncation width,
+// reporting whether any was.
+func (p PrinterOptions) appendTruncated(dst, value []byte) ([]byte, bool) {
+	var cut bool
+	for {
+		i := bytes.IndexByte(value, '\n')
+		line := value
+		if i >= 0 {
+			line = value[:i]
+		}
+		if p.Measure(string(line)) > p.truncateWidth {
+			line = p.fit(line, p.truncateWidth-p.Measure(p.ellipsis))
+			dst = append(dst, line...)
+			dst = append(dst, p.ellipsis...)
+			cut = true
+		} else {
+			dst = append(dst, line...)
+		}
+		if i < 0 {
+			return dst, cut
+		}
+		dst = append(dst, '\n')
+		value = value[i+1:]
+	}
+}
+
+// fit returns the longest prefix of line, cut between runes, not wider than width.
+func (p PrinterOptions) fit(line []byte, width int) []byte {
+	bounds := make([]int, 0, len(line)+1)
+	for i := range string(line) {
+		bounds = append(bounds, i)
+	}
+	bounds = append(bounds, len(line))
+	// the prefixes get wider as they get longer, so the longest fitting one is bisected
+	lo, hi := 0, len(bounds)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if p.Measure(string(line[:bounds[mid]])) <= width {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return line[:bounds[lo]]
+}
@@ -0,0 +1,43 @@
+package treeprint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncation(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddMetaNode("a-long-meta-value", "short")
+	tree.AddNode("a value longer than ten columns")
+	tree.AddNode("日本語のテキスト")
+	tree.AddNode("first line is long\nok")
+
+	full := make(map[Value]string)
+	f := NewPrinter(WithTruncation(10, "…"), WithTruncationHook(func(n *Node, value string) {
+		full[n.Value] = value
+	}))
+	assert.Equal(`.
+├── [a-long-meta-value]  short
+├── a value l…
+├── 日本語の…
+└── first lin…
+    ok
+`, string(tree.Bytes(f)))
+	assert.Len(full, 3)
+	assert.Equal("a value longer than ten columns", full["a value longer than ten columns"])
+
+	f = NewPrinter(WithTruncation(8, "..."), WithMeasureFunc(func(s string) int {
+		return len(s)
+	}))
+	assert.Contains(string(tree.Bytes(f)), "├── a val...\n")
+	assert.Contains(string(tree.Bytes(f)), "├── 日...\n")
+
+	// an ellipsis too wide leaves nothing of the value
+	f = NewPrinter(WithTruncation(2, "[...]"))
+	assert.Contains(string(tree.Bytes(f)), "├── [...]\n")
+	assert.Equal(strings.Count(tree.String(), "\n"), strings.Count(string(tree.Bytes(f)), "\n"))
+}
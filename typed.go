@@ -0,0 +1,114 @@
+package treeprint
+
+// TypedTree is a strongly typed view of a tree whose values are all of type V
+// and whose meta values are all of type M. It shares the underlying nodes,
+// so the untyped Tree returned by Untyped can still be used for rendering options,
+// traversals and everything else not covered by the typed methods.
+type TypedTree[V, M any] struct {
+	n *Node
+}
+
+// NewTyped generates a new typed tree with the given root value.
+func NewTyped[V, M any](root V) TypedTree[V, M] {
+	return TypedTree[V, M]{n: &Node{Value: root}}
+}
+
+// Untyped returns the underlying tree.
+func (t TypedTree[V, M]) Untyped() Tree {
+	return t.n
+}
+
+// Value returns the value of the Node, or the zero V if it is not of type V.
+func (t TypedTree[V, M]) Value() V {
+	v, _ := t.n.Value.(V)
+	return v
+}
+
+// Meta returns the meta value of the Node and whether it has one of type M.
+func (t TypedTree[V, M]) Meta() (M, bool) {
+	m, ok := t.n.Meta.(M)
+	return m, ok
+}
+
+// SetValue sets the value of the Node.
+func (t TypedTree[V, M]) SetValue(v V) {
+	t.n.SetValue(v)
+}
+
+// SetMeta sets the meta value of the Node.
+func (t TypedTree[V, M]) SetMeta(meta M) {
+	t.n.SetMetaValue(meta)
+}
+
+// AddNode adds a new Node to the branch, returning the branch itself.
+func (t TypedTree[V, M]) AddNode(v V) TypedTree[V, M] {
+	t.n.AddNode(v)
+	return t
+}
+
+// AddMetaNode adds a new Node with meta value provided to the branch, returning the branch itself.
+func (t TypedTree[V, M]) AddMetaNode(meta M, v V) TypedTree[V, M] {
+	t.n.AddMetaNode(meta, v)
+	return t
+}
+
+// AddBranch adds a new branch Node (a level deeper).
+func (t TypedTree[V, M]) AddBranch(v V) TypedTree[V, M] {
+	return TypedTree[V, M]{n: t.n.AddBranch(v).(*Node)}
+}
+
+// AddMetaBranch adds a new branch Node (a level deeper) with meta value provided.
+func (t TypedTree[V, M]) AddMetaBranch(meta M, v V) TypedTree[V, M] {
+	return TypedTree[V, M]{n: t.n.AddMetaBranch(meta, v).(*Node)}
+}
+
+// Children returns the children of the Node.
+func (t TypedTree[V, M]) Children() []TypedTree[V, M] {
+	children := t.n.children()
+	typed := make([]TypedTree[V, M], 0, len(children))
+	for _, node := range children {
+		typed = append(typed, TypedTree[V, M]{n: node})
+	}
+	return typed
+}
+
+// VisitAll visits every descendant in depth-first pre-order, same as Tree.VisitAll.
+func (t TypedTree[V, M]) VisitAll(fn func(item TypedTree[V, M])) {
+	t.n.VisitAll(func(item *Node) {
+		fn(TypedTree[V, M]{n: item})
+	})
+}
+
+// Find finds the first descendant, in depth-first order, for which fn reports true.
+func (t TypedTree[V, M]) Find(fn func(item TypedTree[V, M]) bool) (TypedTree[V, M], bool) {
+	node := t.n.find(func(node *Node) bool {
+		return fn(TypedTree[V, M]{n: node})
+	})
+	return TypedTree[V, M]{n: node}, node != nil
+}
+
+// String renders the tree or subtree as a string.
+func (t TypedTree[V, M]) String() string {
+	return t.n.String()
+}
+
+// Print renders the tree or subtree as a string using the given printer options.
+func (t TypedTree[V, M]) Print(f PrinterOptions) string {
+	return t.n.Print(f)
+}
+
+// FindValue finds the first descendant of t whose value is equal to v.
+func FindValue[V comparable, M any](t TypedTree[V, M], v V) (TypedTree[V, M], bool) {
+	return t.Find(func(item TypedTree[V, M]) bool {
+		value, ok := item.n.Value.(V)
+		return ok && value == v
+	})
+}
+
+// FindMeta finds the first descendant of t whose meta value is equal to meta.
+func FindMeta[V any, M comparable](t TypedTree[V, M], meta M) (TypedTree[V, M], bool) {
+	return t.Find(func(item TypedTree[V, M]) bool {
+		m, ok := item.Meta()
+		return ok && m == meta
+	})
+}
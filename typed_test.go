@@ -0,0 +1,48 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedTree(t *testing.T) {
+	assert := assert.New(t)
+
+	type size int64
+	tree := NewTyped[string, size]("/")
+	usr := tree.AddMetaBranch(4096, "usr")
+	usr.AddMetaNode(120, "bin").AddNode("lib")
+	tree.AddNode("tmp")
+
+	assert.Equal(`/
+├── [4096]  usr
+│   ├── [120]  bin
+│   └── lib
+└── tmp
+`, tree.String())
+
+	found, ok := FindValue(tree, "bin")
+	assert.True(ok)
+	meta, ok := found.Meta()
+	assert.True(ok)
+	assert.Equal(size(120), meta)
+
+	found, ok = FindMeta(tree, 4096)
+	assert.True(ok)
+	assert.Equal("usr", found.Value())
+	assert.Len(found.Children(), 2)
+
+	_, ok = FindValue(tree, "missing")
+	assert.False(ok)
+	lib, _ := FindValue(tree, "lib")
+	_, ok = lib.Meta()
+	assert.False(ok)
+
+	var values []string
+	tree.VisitAll(func(item TypedTree[string, size]) {
+		values = append(values, item.Value())
+	})
+	assert.Equal([]string{"usr", "bin", "lib", "tmp"}, values)
+	assert.Equal(2, tree.Untyped().FindByValue("usr").ChildCount())
+}
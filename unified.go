@@ -0,0 +1,159 @@
+package treeprint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ououmania/treeprint/internal/lcs"
+)
+
+// UnifiedDiff renders the differences between the renders of two trees as a unified diff,
+// as diff -u does, with the given number of context lines around the changes.
+// The hunk headers name the ancestors of the first changed Node, as "@@ -4,3 +4,4 @@ a / b",
+// so that the hunks of deep trees can be placed. It returns an empty string for equal renders.
+func UnifiedDiff(want, got Tree, context int) string {
+	return UnifiedDiffText(want.String(), got.String(), context)
+}
+
+// UnifiedDiffText is like UnifiedDiff for two trees already rendered, such as the golden
+// files of tests. The ancestors are found for the default edge styles only.
+func UnifiedDiffText(want, got string, context int) string {
+	a, b := renderLines(want), renderLines(got)
+	if context < 0 {
+		context = 0
+	}
+	ops := lcs.Diff(len(a), len(b), func(i, j int) bool { return a[i] == b[j] })
+	var out strings.Builder
+	for start := 0; start < len(ops); {
+		// a hunk spans the changes separated by at most twice the context lines
+		first := start
+		for first < len(ops) && ops[first].Kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		end := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].Kind == ' ' {
+				if i-end >= 2*context {
+					break
+				}
+				continue
+			}
+			end = i + 1
+		}
+		from := first - context
+		if from < start {
+			from = start
+		}
+		to := end + context
+		if to > len(ops) {
+			to = len(ops)
+		}
+		if out.Len() == 0 {
+			out.WriteString("--- want\n+++ got\n")
+		}
+		hunk := ops[from:to]
+		var aCount, bCount int
+		for _, op := range hunk {
+			if op.Kind != '+' {
+				aCount++
+			}
+			if op.Kind != '-' {
+				bCount++
+			}
+		}
+		var header string
+		if op := ops[first]; op.Kind == '-' {
+			header = lineAncestors(a, op.A)
+		} else {
+			header = lineAncestors(b, op.B)
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@", hunkRange(hunk[0].A, aCount), hunkRange(hunk[0].B, bCount))
+		if header != "" {
+			out.WriteString(" " + header)
+		}
+		out.WriteByte('\n')
+		for _, op := range hunk {
+			text := b[op.B]
+			if op.Kind == '-' {
+				text = a[op.A]
+			}
+			out.WriteByte(op.Kind)
+			out.WriteString(text)
+			out.WriteByte('\n')
+		}
+		start = to
+	}
+	return out.String()
+}
+
+// renderLines splits a render into its lines, without the final newline.
+func renderLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// hunkRange formats the range of a hunk, 1-based, as diff -u does: an empty range
+// is numbered after the line it follows.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprint(start + 1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// lineAncestors returns the values of the ancestors of the Node rendered on the line i,
+// joined by " / ", as read from the prefixes of the lines above it.
+func lineAncestors(lines []string, i int) string {
+	if i >= len(lines) {
+		return ""
+	}
+	depth, _ := lineDepth(lines[i], i)
+	var ancestors []string
+	for j := i - 1; j >= 0 && depth > 0; j-- {
+		if d, text := lineDepth(lines[j], j); d >= 0 && d < depth {
+			ancestors = append(ancestors, text)
+			depth = d
+		}
+	}
+	for l, r := 0, len(ancestors)-1; l < r; l, r = l+1, r-1 {
+		ancestors[l], ancestors[r] = ancestors[r], ancestors[l]
+	}
+	return strings.Join(ancestors, " / ")
+}
+
+// lineDepth returns the depth of the Node rendered on the line i and its text,
+// or -1 for the extra lines of multiline values and the lines that are not part of a tree.
+func lineDepth(line string, i int) (int, string) {
+	depth := 0
+	for {
+		if rest, ok := trimAnyPrefix(line, "│   ", "|   ", "    "); ok {
+			line = rest
+			depth++
+			continue
+		}
+		if rest, ok := trimAnyPrefix(line, "├── ", "└── ", "|-- ", "`-- "); ok {
+			return depth + 1, rest
+		}
+		if depth == 0 && i == 0 {
+			return 0, line
+		}
+		return -1, ""
+	}
+}
+
+func trimAnyPrefix(s string, prefixes ...string) (string, bool) {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return s[len(prefix):], true
+		}
+	}
+	return s, false
+}
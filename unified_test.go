@@ -0,0 +1,68 @@
+package treeprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	build := func(extra bool) Tree {
+		tree := NewWithRoot("root")
+		one := tree.AddBranch("one")
+		for _, v := range []string{"a", "b", "c", "d", "e", "f"} {
+			one.AddNode(v)
+		}
+		deep := tree.AddBranch("two").AddBranch("deep")
+		deep.AddNode("x")
+		if extra {
+			deep.AddNode("y")
+		} else {
+			deep.AddNode("z")
+		}
+		return tree
+	}
+
+	assert.Empty(UnifiedDiff(build(false), build(false), 3))
+	assert.Equal(`--- want
++++ got
+@@ -11,2 +11,2 @@ root / two / deep
+         ├── x
+-        └── z
++        └── y
+`, UnifiedDiff(build(false), build(true), 1))
+
+	want := "root\n├── a\n├── b\n├── c\n├── d\n├── e\n└── f\n"
+	got := "root\n├── a\n├── B\n├── c\n├── d\n├── e\n├── f\n└── g\n"
+	assert.Equal(`--- want
++++ got
+@@ -2,3 +2,3 @@ root
+ ├── a
+-├── b
++├── B
+ ├── c
+@@ -6,2 +6,3 @@ root
+ ├── e
+-└── f
++├── f
++└── g
+`, UnifiedDiffText(want, got, 1))
+	assert.Equal(`--- want
++++ got
+@@ -1,7 +1,8 @@ root
+ root
+ ├── a
+-├── b
++├── B
+ ├── c
+ ├── d
+ ├── e
+-└── f
++├── f
++└── g
+`, UnifiedDiffText(want, got, 3))
+
+	assert.Equal("--- want\n+++ got\n@@ -0,0 +1 @@\n+x\n", UnifiedDiffText("", "x\n", 3))
+}
@@ -0,0 +1,99 @@
+package treeprint
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrCycle is reported when a Node is reachable from one of its own descendants.
+	ErrCycle = errors.New("treeprint: cycle detected")
+	// ErrNilChild is reported when a branch holds a nil child Node.
+	ErrNilChild = errors.New("treeprint: nil child node")
+	// ErrBadRoot is reported when a child's Root does not point to the branch holding it.
+	ErrBadRoot = errors.New("treeprint: root pointer mismatch")
+	// ErrSharedNode is reported when the same Node is held by more than one branch.
+	ErrSharedNode = errors.New("treeprint: node shared between branches")
+)
+
+// Validate checks the invariants of the tree below n: there are no cycles,
+// no nil children, no Node is held by two branches and each child's Root
+// points to the branch holding it. The first violation found is returned.
+func (n *Node) Validate() error {
+	if n == nil {
+		return nil
+	}
+	visited := map[*Node]bool{n: true}
+	// path holds the nodes currently on the stack, reaching one of them again is a cycle
+	path := map[*Node]bool{n: true}
+	stack := []walkFrame{{parent: n}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.parent.Nodes) {
+			delete(path, top.parent)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		parent, i := top.parent, top.i
+		node := parent.Nodes[i]
+		top.i++
+		if node == nil {
+			return fmt.Errorf("%w: child %d of %v", ErrNilChild, i, parent.Value)
+		}
+		if path[node] {
+			return fmt.Errorf("%w: %v is its own ancestor", ErrCycle, node.Value)
+		}
+		if visited[node] {
+			return fmt.Errorf("%w: %v", ErrSharedNode, node.Value)
+		}
+		if node.Root != parent {
+			return fmt.Errorf("%w: %v is not linked to %v", ErrBadRoot, node.Value, parent.Value)
+		}
+		visited[node] = true
+		path[node] = true
+		stack = append(stack, walkFrame{parent: node})
+	}
+	return nil
+}
+
+// Repair restores the invariants checked by Validate: nil children are dropped,
+// edges leading to an already seen Node (cycles and shared nodes) are cut and
+// every remaining child gets its Root pointed to the branch holding it.
+func (n *Node) Repair() {
+	if n == nil {
+		return
+	}
+	type frame struct {
+		node *Node
+		i    int
+		kept int
+	}
+	// the first occurrence of a Node in pre-order wins, later ones are cut
+	visited := map[*Node]bool{n: true}
+	stack := []frame{{node: n}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.i == len(top.node.Nodes) {
+			if top.kept < len(top.node.Nodes) {
+				top.node.Nodes = top.node.Nodes[:top.kept]
+				top.node.MarkDirty()
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		child := top.node.Nodes[top.i]
+		top.i++
+		if child == nil || visited[child] {
+			continue
+		}
+		visited[child] = true
+		if child.Root != top.node {
+			child.Root = top.node
+			child.MarkDirty()
+		}
+		top.node.Nodes[top.kept] = child
+		child.index = top.kept
+		top.kept++
+		stack = append(stack, frame{node: child})
+	}
+}
@@ -0,0 +1,85 @@
+package treeprint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New()
+	tree.AddBranch("one").AddNode("two")
+	tree.AddNode("three")
+	assert.NoError(tree.Validate())
+
+	root := tree.(*Node)
+	one := root.Nodes[0]
+
+	one.Root = nil
+	assert.ErrorIs(tree.Validate(), ErrBadRoot)
+	tree.Repair()
+	assert.NoError(tree.Validate())
+	assert.Equal(root, one.Root)
+
+	one.Nodes = append(one.Nodes, nil)
+	assert.ErrorIs(tree.Validate(), ErrNilChild)
+	tree.Repair()
+	assert.NoError(tree.Validate())
+	assert.Len(one.Nodes, 1)
+
+	root.Nodes = append(root.Nodes, one.Nodes[0])
+	assert.ErrorIs(tree.Validate(), ErrSharedNode)
+	tree.Repair()
+	assert.NoError(tree.Validate())
+	assert.Len(root.Nodes, 2)
+
+	one.Nodes[0].Nodes = append(one.Nodes[0].Nodes, one)
+	assert.ErrorIs(tree.Validate(), ErrCycle)
+	tree.Repair()
+	assert.NoError(tree.Validate())
+	assert.Equal(`.
+├── one
+│   └── two
+└── three
+`, tree.String())
+}
+
+func TestRenderCycle(t *testing.T) {
+	assert := assert.New(t)
+
+	tree := New().(*Node)
+	a := tree.AddBranch("a").(*Node)
+	b := a.AddBranch("b").(*Node)
+	b.AddNode("c")
+	// bypass AddTree, which refuses to create cycles
+	b.Nodes = append(b.Nodes, a)
+	a.Nodes = append(a.Nodes, tree)
+
+	expected := `.
+└── a
+    ├── b
+    │   ├── c
+    │   └── a (cycle)
+    └── . (cycle)
+`
+	assert.Equal(expected, tree.String())
+	var buf bytes.Buffer
+	_, err := tree.PrintToParallel(&buf, NewPrinter(), 2)
+	assert.NoError(err)
+	assert.Equal(expected, buf.String())
+	assert.Equal(`.
+└── a
+    ├── b
+    │   ├── c
+… output truncated (2 nodes omitted)`, tree.Print(NewPrinter(WithMaxNodes(3))))
+
+	var visited []Value
+	tree.VisitAll(func(item *Node) {
+		visited = append(visited, item.Value)
+	})
+	assert.Equal([]Value{"a", "b", "c", "a", "."}, visited)
+	assert.ErrorIs(tree.Validate(), ErrCycle)
+}
@@ -0,0 +1,117 @@
+package treeprint
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+)
+
+// ValueCache keeps the formatted meta and value of the rendered nodes, so that
+// rendering a mostly-static tree over and over neither calls the print functions
+// nor looks for line breaks again for the unchanged nodes. See WithValueCache.
+//
+// The entries are stored on the nodes themselves and invalidated by MarkDirty,
+// which SetValue, SetMetaValue and the other mutating methods call. Nodes changed
+// by assigning their fields directly must be marked dirty by hand.
+// The entries are read and stored under a lock, so that concurrent renders of a tree,
+// such as the ones of Cow views sharing a base tree, may use a ValueCache.
+type ValueCache struct {
+	gen uint64
+}
+
+// formattedMu guards the entries stored on the nodes, which the renders with
+// different caches may share.
+var formattedMu sync.RWMutex
+
+// NewValueCache creates an empty ValueCache.
+func NewValueCache() *ValueCache {
+	return &ValueCache{}
+}
+
+// Reset invalidates all the entries, e.g. after the print functions changed their output.
+func (c *ValueCache) Reset() {
+	atomic.AddUint64(&c.gen, 1)
+}
+
+// WithValueCache caches the formatted meta and value of the nodes in c.
+// The cache must only be shared by printers using the same print functions.
+func WithValueCache(c *ValueCache) Option {
+	return func(p *PrinterOptions) {
+		p.valueCache = c
+	}
+}
+
+// cachedValue is the formatted meta and value of a Node, valid while
+// the Node revision and the cache generation are unchanged.
+type cachedValue struct {
+	cache     *ValueCache
+	gen       uint64
+	rev       uint64
+	depth     int
+	meta      []byte
+	value     []byte
+	multiline bool
+}
+
+func (v *cachedValue) valid(c *ValueCache, n *Node, depth int) bool {
+	return v != nil && v.cache == c && v.gen == atomic.LoadUint64(&c.gen) && v.rev == n.rev && v.depth == depth
+}
+
+// format returns the formatted meta and value of a Node of the depth, from the cache if any.
+// Unless cached, the result is only valid until the next call.
+func (p *printer) format(node *Node, depth int) (meta, value []byte, multiline bool) {
+	c := p.pf.valueCache
+	if c != nil {
+		formattedMu.RLock()
+		v := node.formatted
+		formattedMu.RUnlock()
+		if v.valid(c, node, depth) {
+			return v.meta, v.value, v.multiline
+		}
+	}
+	p.value.Reset()
+	if node.Meta != nil {
+		p.pf.printMeta(node.Meta, depth, &p.value)
+	}
+	m := p.value.Len()
+	p.pf.printValue(node.Value, &p.value)
+	b := p.value.Bytes()
+	if p.pf.normalize && !p.pf.normForm.IsNormal(b) {
+		p.normalized = p.pf.appendNormalized(p.normalized[:0], b[:m])
+		n := len(p.normalized)
+		p.normalized = p.pf.appendNormalized(p.normalized, b[m:])
+		m, b = n, p.normalized
+	}
+	if p.pf.controlChars != KeepControlChars && hasControl(b) {
+		p.control = p.pf.appendControl(p.control[:0], b[:m])
+		n := len(p.control)
+		p.control = p.pf.appendControl(p.control, b[m:])
+		m, b = n, p.control
+	}
+	if p.pf.truncateWidth > 0 {
+		var cut bool
+		p.truncated = append(p.truncated[:0], b[:m]...)
+		if p.truncated, cut = p.pf.appendTruncated(p.truncated, b[m:]); cut {
+			if p.pf.truncationHook != nil {
+				p.pf.truncationHook(node, string(b[m:]))
+			}
+			b = p.truncated
+		}
+	}
+	multiline = bytes.IndexByte(b[m:], '\n') >= 0
+	if c != nil {
+		b = append([]byte(nil), b...)
+		formattedMu.Lock()
+		node.formatted = &cachedValue{
+			cache:     c,
+			gen:       atomic.LoadUint64(&c.gen),
+			rev:       node.rev,
+			depth:     depth,
+			meta:      b[:m:m],
+			value:     b[m:],
+			multiline: multiline,
+		}
+		formattedMu.Unlock()
+	}
+	return b[:m], b[m:], multiline
+}
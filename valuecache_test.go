@@ -0,0 +1,60 @@
+package treeprint
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueCache(t *testing.T) {
+	assert := assert.New(t)
+
+	calls := 0
+	cache := NewValueCache()
+	pf := NewPrinter(WithValueCache(cache), WithValuePrint(func(v Value, w io.Writer) {
+		calls++
+		fmt.Fprintf(w, "%v", v)
+	}))
+
+	tree := New()
+	one := tree.AddMetaBranch("m", "one")
+	one.AddNode("a\nb")
+	one.AddNode("c")
+	tree.AddNode("two")
+
+	expected := `.
+├── [m]  one
+│   ├── a
+│   │   b
+│   └── c
+└── two`
+	assert.Equal(expected, tree.Print(pf))
+	assert.Equal(5, calls)
+	assert.Equal(expected, tree.Print(pf))
+	assert.Equal(5, calls, "the root is cached like the other nodes")
+
+	tree.SetValue("root")
+	assert.Equal("root"+expected[1:], tree.Print(pf))
+	assert.Equal(6, calls, "only the changed root is formatted again")
+	tree.SetValue(".")
+	assert.Equal(expected, tree.Print(pf))
+	assert.Equal(7, calls)
+
+	one.FindLastNode().SetValue("d")
+	assert.Equal(`.
+├── [m]  one
+│   ├── a
+│   │   b
+│   └── d
+└── two`, tree.Print(pf))
+	assert.Equal(8, calls, "only the changed node is formatted again")
+
+	cache.Reset()
+	tree.Print(pf)
+	assert.Equal(13, calls)
+
+	other := NewPrinter(WithValueCache(NewValueCache()))
+	assert.Equal(tree.String(), string(tree.Bytes(other)))
+}